@@ -3,133 +3,43 @@ package main
 import (
 	"bufio"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"net/url"
 	"os"
 	"sort"
-	"strings"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/P-Brennan3/options/pkg/export"
+	"github.com/P-Brennan3/options/pkg/marketdata"
+	"github.com/P-Brennan3/options/pkg/pricing"
+	"github.com/P-Brennan3/options/pkg/schwab"
+	"github.com/P-Brennan3/options/pkg/store"
+	"github.com/P-Brennan3/options/pkg/strategy"
+	"github.com/P-Brennan3/options/pkg/stream"
 	"github.com/joho/godotenv"
-	"golang.org/x/time/rate"
 )
 
-type Underlying struct {
-	PercentChange    float64 `json:"percentChange"`
-	Last             float64 `json:"last"`
-	FiftyTwoWeekHigh float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow  float64 `json:"fiftyTwoWeekLow"`
-}
+// defaultRiskFreeRate is used when RISK_FREE_RATE isn't set in the
+// environment and FRED_API_KEY isn't available to fetch a live yield.
+const defaultRiskFreeRate = 0.05
 
-type OptionContract struct {
-	PutCall                string  `json:"putCall"`
-	Symbol                 string  `json:"symbol"`
-	Description            string  `json:"description"`
-	ExchangeName           string  `json:"exchangeName"`
-	Bid                    float64 `json:"bid"`
-	Ask                    float64 `json:"ask"`
-	Last                   float64 `json:"last"`
-	Mark                   float64 `json:"mark"`
-	BidSize                int     `json:"bidSize"`
-	AskSize                int     `json:"askSize"`
-	BidAskSize             string  `json:"bidAskSize"`
-	LastSize               int     `json:"lastSize"`
-	HighPrice              float64 `json:"highPrice"`
-	LowPrice               float64 `json:"lowPrice"`
-	OpenPrice              float64 `json:"openPrice"`
-	ClosePrice             float64 `json:"closePrice"`
-	TotalVolume            int     `json:"totalVolume"`
-	TradeTimeInLong        int64   `json:"tradeTimeInLong"`
-	QuoteTimeInLong        int64   `json:"quoteTimeInLong"`
-	NetChange              float64 `json:"netChange"`
-	Volatility             float64 `json:"volatility"`
-	Delta                  float64 `json:"delta"`
-	Gamma                  float64 `json:"gamma"`
-	Theta                  float64 `json:"theta"`
-	Vega                   float64 `json:"vega"`
-	Rho                    float64 `json:"rho"`
-	OpenInterest           int     `json:"openInterest"`
-	TimeValue              float64 `json:"timeValue"`
-	TheoreticalOptionValue float64 `json:"theoreticalOptionValue"`
-	TheoreticalVolatility  float64 `json:"theoreticalVolatility"`
-	StrikePrice            float64 `json:"strikePrice"`
-	ExpirationDate         string  `json:"expirationDate"`
-	DaysToExpiration       int     `json:"daysToExpiration"`
-	ExpirationType         string  `json:"expirationType"`
-	LastTradingDay         int64   `json:"lastTradingDay"`
-	Multiplier             float64 `json:"multiplier"`
-	SettlementType         string  `json:"settlementType"`
-	DeliverableNote        string  `json:"deliverableNote"`
-	PercentChange          float64 `json:"percentChange"`
-	MarkChange             float64 `json:"markChange"`
-	MarkPercentChange      float64 `json:"markPercentChange"`
-	IntrinsicValue         float64 `json:"intrinsicValue"`
-	ExtrinsicValue         float64 `json:"extrinsicValue"`
-	InTheMoney             bool    `json:"inTheMoney"`
-}
+// defaultDividendYield is used when DIVIDEND_YIELD isn't set in the
+// environment. Schwab's option chain doesn't report the underlying's
+// dividend yield, so there's no per-symbol value to fall back to.
+const defaultDividendYield = 0.0
 
-type OptionsChain struct {
-	Symbol         string                                 `json:"symbol"`
-	Underlying     Underlying                             `json:"underlying"`
-	CallExpDateMap map[string]map[string][]OptionContract `json:"callExpDateMap"`
-	PutExpDateMap  map[string]map[string][]OptionContract `json:"putExpDateMap"`
-}
-
-type Option struct {
-	Symbol                 string
-	Description            string
-	ExchangeName           string
-	LastStockPrice         float64
-	stockPercentChange     float64
-	lastPrice              float64
-	fiftyTwoWeekHigh       float64
-	fiftyTwoWeekLow        float64
-	optionType             string
-	OptionSymbol           string  `json:"symbol"`
-	Bid                    float64 `json:"bid"`
-	Ask                    float64 `json:"ask"`
-	Last                   float64 `json:"last"`
-	Mark                   float64 `json:"mark"`
-	BidSize                int     `json:"bidSize"`
-	AskSize                int     `json:"askSize"`
-	BidAskSize             string  `json:"bidAskSize"`
-	LastSize               int     `json:"lastSize"`
-	HighPrice              float64 `json:"highPrice"`
-	LowPrice               float64 `json:"lowPrice"`
-	OpenPrice              float64 `json:"openPrice"`
-	ClosePrice             float64 `json:"closePrice"`
-	TotalVolume            int     `json:"totalVolume"`
-	NetChange              float64 `json:"netChange"`
-	Volatility             float64 `json:"volatility"`
-	Delta                  float64 `json:"delta"`
-	Gamma                  float64 `json:"gamma"`
-	Theta                  float64 `json:"theta"`
-	Vega                   float64 `json:"vega"`
-	Rho                    float64 `json:"rho"`
-	OpenInterest           int     `json:"openInterest"`
-	TimeValue              float64 `json:"timeValue"`
-	TheoreticalOptionValue float64 `json:"theoreticalOptionValue"`
-	TheoreticalVolatility  float64 `json:"theoreticalVolatility"`
-	StrikePrice            float64 `json:"strikePrice"`
-	ExpirationDate         string  `json:"expirationDate"`
-	DaysToExpiration       int     `json:"daysToExpiration"`
-	LastTradingDay         int64   `json:"lastTradingDay"`
-	PercentChange          float64 `json:"percentChange"`
-	MarkChange             float64 `json:"markChange"`
-	MarkPercentChange      float64 `json:"markPercentChange"`
-	IntrinsicValue         float64 `json:"intrinsicValue"`
-	ExtrinsicValue         float64 `json:"extrinsicValue"`
-	InTheMoney             bool    `json:"inTheMoney"`
-}
+// defaultDBPath is where scan snapshots are persisted between runs.
+const defaultDBPath = "scans.db"
 
 func main() {
-	err := godotenv.Load()
-	if err != nil {
+	rankMode := flag.String("rank", "iv", `ranking mode: "iv" (highest/lowest implied volatility), "mispricing" (|market mid - theoretical price| / vega), or "strategies" (best-scoring multi-leg trades)`)
+	exportFormat := flag.String("export", "", `export the scan snapshot to a file: "csv" or "parquet" (disabled by default)`)
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
 		log.Fatal("Error loading .env file")
 	}
 
@@ -139,12 +49,15 @@ func main() {
 		log.Fatal("APP_KEY and SECRET_KEY must be set in the environment")
 	}
 
-	// Get the initial access token
-	accessToken, refreshToken, err := getInitialToken(appKey, secretKey)
+	client, err := schwab.New(schwab.Config{AppKey: appKey, SecretKey: secretKey})
 	if err != nil {
-		log.Fatalf("Error getting initial token: %v", err)
+		log.Fatalf("Error creating Schwab client: %v", err)
 	}
 
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	defer stopRefresh()
+	client.StartTokenRefresh(refreshCtx)
+
 	stockTickers, err := readStocksFile("tickers.stocks")
 	if err != nil {
 		log.Fatalf("Error reading stocks file: %v", err)
@@ -152,37 +65,299 @@ func main() {
 
 	fmt.Printf("Fetching options data for %d stocks\n", len(stockTickers))
 
-	limiter := rate.NewLimiter(rate.Limit(120), 120)
+	options := scan(refreshCtx, client, stockTickers)
+	scanTime := time.Now()
 
-	jobs := make(chan string, len(stockTickers))
-	results := make(chan []Option, len(stockTickers))
+	if err := persistSnapshot(refreshCtx, scanTime, options); err != nil {
+		log.Printf("Error persisting scan snapshot: %v", err)
+	}
 
-	numWorkers := 10
-	for i := 0; i < numWorkers; i++ {
-		go worker(jobs, results, limiter, accessToken, refreshToken, appKey, secretKey)
+	if *exportFormat != "" {
+		if err := exportSnapshot(export.Format(*exportFormat), scanTime, options); err != nil {
+			log.Printf("Error exporting scan snapshot: %v", err)
+		}
 	}
 
-	for _, stock := range stockTickers {
-		jobs <- stock
+	rateSource := riskFreeRateSource()
+	dividendSource := dividendYieldSource()
+	printRanked(refreshCtx, options, *rankMode, rateSource, dividendSource)
+
+	book := newLiveBook(options)
+
+	if err := startLiveStream(refreshCtx, client, book); err != nil {
+		log.Printf("Streaming quotes disabled: %v", err)
+		return
 	}
-	close(jobs)
 
-	var options []Option
-	for i := 0; i < len(stockTickers); i++ {
-		options = append(options, <-results...)
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-refreshCtx.Done():
+			return
+		case <-ticker.C:
+			printRanked(refreshCtx, book.snapshot(), *rankMode, rateSource, dividendSource)
+		}
+	}
+}
+
+// persistSnapshot writes options to the local SQLite store, keyed by
+// (scanTime, option symbol), so later runs can compute IV rank/percentile
+// over history.
+func persistSnapshot(ctx context.Context, scanTime time.Time, options []marketdata.Option) error {
+	s, err := store.Open(ctx, defaultDBPath)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", defaultDBPath, err)
+	}
+	defer s.Close()
+
+	if err := s.SaveSnapshot(ctx, scanTime, options); err != nil {
+		return fmt.Errorf("saving snapshot: %w", err)
+	}
+	return nil
+}
+
+// exportSnapshot writes options to a scan-<timestamp>.<format> file in the
+// requested format.
+func exportSnapshot(format export.Format, scanTime time.Time, options []marketdata.Option) error {
+	filename := fmt.Sprintf("scan-%s.%s", scanTime.Format("20060102T150405"), format)
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	if err := export.Write(f, format, options); err != nil {
+		return fmt.Errorf("writing %s: %w", filename, err)
+	}
+
+	fmt.Printf("Exported %d options to %s\n", len(options), filename)
+	return nil
+}
+
+// riskFreeRateSource builds the pricing.RateSource used for mispricing
+// ranking, from RISK_FREE_RATE/FRED_API_KEY environment variables, falling
+// back to defaultRiskFreeRate.
+func riskFreeRateSource() pricing.RateSource {
+	if apiKey := os.Getenv("FRED_API_KEY"); apiKey != "" {
+		return pricing.NewTreasuryRate(apiKey)
+	}
+
+	rate := defaultRiskFreeRate
+	if raw := os.Getenv("RISK_FREE_RATE"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			log.Printf("Invalid RISK_FREE_RATE %q, using default %v: %v", raw, defaultRiskFreeRate, err)
+		} else {
+			rate = parsed
+		}
+	}
+	return pricing.FlatRate(rate)
+}
+
+// dividendYieldSource builds the pricing.DividendSource used for mispricing
+// ranking, from the DIVIDEND_YIELD environment variable, falling back to
+// defaultDividendYield.
+func dividendYieldSource() pricing.DividendSource {
+	yield := defaultDividendYield
+	if raw := os.Getenv("DIVIDEND_YIELD"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			log.Printf("Invalid DIVIDEND_YIELD %q, using default %v: %v", raw, defaultDividendYield, err)
+		} else {
+			yield = parsed
+		}
 	}
+	return pricing.FlatDividend(yield)
+}
 
-	sort.Slice(options, func(i, j int) bool {
-		return options[i].Volatility > options[j].Volatility
+// printRanked prints options using the ranking mode selected on the command
+// line.
+func printRanked(ctx context.Context, options []marketdata.Option, rankMode string, rateSource pricing.RateSource, dividendSource pricing.DividendSource) {
+	switch rankMode {
+	case "mispricing":
+		printTopMispricing(ctx, options, rateSource, dividendSource)
+	case "strategies":
+		printTopStrategies(options)
+	default:
+		printTopIV(options)
+	}
+}
+
+// printTopStrategies groups options back into a per-symbol chain, runs
+// strategy.Default against each, and prints the 20 best-scoring multi-leg
+// trades across every symbol scanned, ranked by score rather than by
+// whichever symbol happened to be enumerated first.
+func printTopStrategies(options []marketdata.Option) {
+	bySymbol := make(map[string][]marketdata.Option)
+	for _, o := range options {
+		bySymbol[o.Symbol] = append(bySymbol[o.Symbol], o)
+	}
+
+	var scored []strategy.ScoredTrade
+	for symbol, symbolOptions := range bySymbol {
+		chain := marketdata.OptionsChain{Symbol: symbol, Options: symbolOptions}
+		scored = append(scored, strategy.ScanScored(chain, strategy.Default)...)
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
 	})
 
+	fmt.Printf("Best-scoring multi-leg trades\n")
+	limit := 20
+	if len(scored) < limit {
+		limit = len(scored)
+	}
+	for _, st := range scored[:limit] {
+		t := st.Trade
+		legSymbols := make([]string, len(t.Legs))
+		for i, leg := range t.Legs {
+			side := "+"
+			if leg.Side == strategy.Short {
+				side = "-"
+			}
+			legSymbols[i] = side + leg.Option.OptionSymbol
+		}
+		fmt.Printf("%-12s Score:%6.3f NetDebit:$%+.2f MaxProfit:$%.2f MaxLoss:$%.2f %v\n",
+			t.Strategy,
+			st.Score,
+			t.NetDebit,
+			t.MaxProfit,
+			t.MaxLoss,
+			legSymbols,
+		)
+	}
+}
+
+// startLiveStream looks up the account's streamer connection details,
+// subscribes to every option symbol currently in book, and applies
+// incoming updates to it until ctx is cancelled.
+func startLiveStream(ctx context.Context, client *schwab.Client, book *liveBook) error {
+	info, err := client.StreamerInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching streamer info: %w", err)
+	}
+
+	streamer := stream.New(stream.Config{
+		StreamerURL: info.StreamerURL,
+		CustomerID:  info.CustomerID,
+		CorrelID:    info.CorrelID,
+		Channel:     info.Channel,
+		FunctionID:  info.FunctionID,
+	}, func() string {
+		accessToken, _ := client.Tokens()
+		return accessToken
+	})
+	streamer.Subscribe(book.symbols()...)
+	streamer.Start(ctx)
+
+	go func() {
+		for update := range streamer.Updates() {
+			book.apply(update)
+		}
+	}()
+
+	return nil
+}
+
+// liveBook holds the most recent snapshot of scanned options and keeps it
+// up to date as streaming quotes arrive, so the top/bottom IV lists reflect
+// live market data instead of a one-shot scan.
+type liveBook struct {
+	mu      sync.Mutex
+	byIndex []marketdata.Option
+	indexOf map[string]int
+}
+
+func newLiveBook(options []marketdata.Option) *liveBook {
+	indexOf := make(map[string]int, len(options))
+	for i, o := range options {
+		indexOf[o.OptionSymbol] = i
+	}
+	return &liveBook{byIndex: options, indexOf: indexOf}
+}
+
+func (b *liveBook) symbols() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	symbols := make([]string, len(b.byIndex))
+	for i, o := range b.byIndex {
+		symbols[i] = o.OptionSymbol
+	}
+	return symbols
+}
+
+func (b *liveBook) apply(u stream.OptionUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i, ok := b.indexOf[u.OptionSymbol]
+	if !ok {
+		return
+	}
+	option := &b.byIndex[i]
+	if u.Bid != nil {
+		option.Bid = *u.Bid
+	}
+	if u.Ask != nil {
+		option.Ask = *u.Ask
+	}
+	if u.Mark != nil {
+		option.Mark = *u.Mark
+	}
+	if u.Volatility != nil {
+		option.Volatility = *u.Volatility
+	}
+	if u.Delta != nil {
+		option.Delta = *u.Delta
+	}
+	if u.Gamma != nil {
+		option.Gamma = *u.Gamma
+	}
+	if u.Theta != nil {
+		option.Theta = *u.Theta
+	}
+	if u.Vega != nil {
+		option.Vega = *u.Vega
+	}
+	if u.Rho != nil {
+		option.Rho = *u.Rho
+	}
+}
+
+func (b *liveBook) snapshot() []marketdata.Option {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	options := make([]marketdata.Option, len(b.byIndex))
+	copy(options, b.byIndex)
+	return options
+}
+
+// printTopIV ranks options by implied volatility using strategy.SingleContract
+// (the one-leg Strategy that scores a Trade by its contract's volatility) and
+// prints the top and bottom 40, the same ranking the one-shot scan used to
+// print once at exit. This is the same scan printTopStrategies runs, just
+// restricted to the single-contract Strategy and kept in its own report
+// format.
+func printTopIV(options []marketdata.Option) {
+	chain := marketdata.OptionsChain{Options: options}
+	scored := strategy.ScanScored(chain, []strategy.Strategy{strategy.SingleContract{}})
+
+	limit := 40
+	if len(scored) < limit {
+		limit = len(scored)
+	}
+
 	fmt.Printf("Options with the greatest IV\n")
-	for _, option := range options[:40] {
+	for _, st := range scored[:limit] {
+		option := st.Legs[0].Option
 		fmt.Printf("%-5s ($%4.2f) %10s %4s@$%.2f IV:%5.2f%% Trading at $%4.2f [%s]\n",
 			option.Symbol,
 			option.LastStockPrice,
 			option.ExpirationDate[:10],
-			option.optionType,
+			option.OptionType,
 			option.StrikePrice,
 			option.Volatility,
 			option.Ask,
@@ -191,13 +366,13 @@ func main() {
 	}
 
 	fmt.Printf("\nOptions with the lowest IV\n")
-	for i := 0; i < 40; i++ {
-		option := options[len(options)-1-i]
+	for i := 0; i < limit; i++ {
+		option := scored[len(scored)-1-i].Legs[0].Option
 		fmt.Printf("%-5s ($%4.2f) %10s %4s@$%.2f IV:%5.2f%% Trading at $%4.2f [%s]\n",
 			option.Symbol,
 			option.LastStockPrice,
 			option.ExpirationDate[:10],
-			option.optionType,
+			option.OptionType,
 			option.StrikePrice,
 			option.Volatility,
 			option.Last,
@@ -206,261 +381,87 @@ func main() {
 	}
 }
 
-func getInitialToken(appKey, secretKey string) (string, string, error) {
-    authURL := "https://api.schwabapi.com/v1/oauth/authorize"
-    tokenURL := "https://api.schwabapi.com/v1/oauth/token"
-    redirectURL := "https://127.0.0.1"
-
-    // Step 1: Get authorization code
-    authCodeURL := fmt.Sprintf("%s?client_id=%s&redirect_uri=%s", 
-        authURL, appKey, redirectURL)
-
-    fmt.Printf("Visit this URL to authorize the application: %v\n", authCodeURL)
-    fmt.Println("After authorization, you will be redirected. Copy and paste the ENTIRE redirected URL here:")
-
-    var redirectURIWithCode string
-    fmt.Scanln(&redirectURIWithCode)
-
-    parsedURL, err := url.Parse(redirectURIWithCode)
-    if err != nil {
-        return "", "", fmt.Errorf("couldn't parse redirect URI: %v", err)
-    }
-    code := parsedURL.Query().Get("code")
-    if code == "" {
-        return "", "", fmt.Errorf("no code found in redirect URI")
-    }
-
-    // Step 2: Exchange authorization code for tokens
-    data := url.Values{}
-    data.Set("grant_type", "authorization_code")
-    data.Set("code", code)
-    data.Set("redirect_uri", redirectURL)
-
-    req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
-    if err != nil {
-        return "", "", fmt.Errorf("error creating token request: %v", err)
-    }
-
-    // Set headers
-    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-    authHeader := base64.StdEncoding.EncodeToString([]byte(appKey + ":" + secretKey))
-    req.Header.Set("Authorization", "Basic "+authHeader)
-
-    client := &http.Client{}
-    resp, err := client.Do(req)
-    if err != nil {
-        return "", "", fmt.Errorf("error exchanging code for token: %v", err)
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK {
-        body, _ := io.ReadAll(resp.Body)
-        return "", "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, string(body))
-    }
-
-    var result struct {
-        AccessToken  string `json:"access_token"`
-        RefreshToken string `json:"refresh_token"`
-    }
-
-    err = json.NewDecoder(resp.Body).Decode(&result)
-    if err != nil {
-        return "", "", fmt.Errorf("error decoding token response: %v", err)
-    }
-
-    return result.AccessToken, result.RefreshToken, nil
+// rankedMispricing pairs an option with its pricing.Evaluation so the two
+// can be sorted and printed together.
+type rankedMispricing struct {
+	option marketdata.Option
+	eval   pricing.Evaluation
 }
 
-func worker(jobs <-chan string, results chan<- []Option, limiter *rate.Limiter, accessToken, refreshToken, appKey, secretKey string) {
-	for stock := range jobs {
-		err := limiter.Wait(context.Background())
+// printTopMispricing re-prices every option under Black-Scholes and prints
+// the 40 most exploitable quotes, ranked by |Mispricing|/vega rather than
+// raw implied volatility.
+func printTopMispricing(ctx context.Context, options []marketdata.Option, rateSource pricing.RateSource, dividendSource pricing.DividendSource) {
+	var ranked []rankedMispricing
+	for _, option := range options {
+		eval, err := pricing.Evaluate(ctx, option, rateSource, dividendSource)
 		if err != nil {
-			log.Printf("Rate limiter error: %v", err)
-			continue
-		}
-
-		options, newAccessToken, newRefreshToken, err := getOptionsData(stock, accessToken, refreshToken, appKey, secretKey)
-		if err != nil {
-			log.Printf("Error getting options data for %s: %v", stock, err)
+			log.Printf("Skipping %s: %v", option.OptionSymbol, err)
 			continue
 		}
+		ranked = append(ranked, rankedMispricing{option: option, eval: eval})
+	}
 
-		// Update tokens if they've changed
-		if newAccessToken != "" {
-			accessToken = newAccessToken
-		}
-		if newRefreshToken != "" {
-			refreshToken = newRefreshToken
-		}
+	sort.Slice(ranked, func(i, j int) bool {
+		return ranked[i].eval.Score() > ranked[j].eval.Score()
+	})
 
-		results <- options
+	fmt.Printf("Most mispriced options (|market mid - theoretical| / vega)\n")
+	limit := 40
+	if len(ranked) < limit {
+		limit = len(ranked)
+	}
+	for _, r := range ranked[:limit] {
+		fmt.Printf("%-5s ($%4.2f) %10s %4s@$%.2f Theo:$%.2f Mispricing:$%+.2f IV:%5.2f%% [%s]\n",
+			r.option.Symbol,
+			r.option.LastStockPrice,
+			r.option.ExpirationDate[:10],
+			r.option.OptionType,
+			r.option.StrikePrice,
+			r.eval.Theoretical,
+			r.eval.Mispricing,
+			r.eval.IV*100,
+			r.option.OptionSymbol,
+		)
 	}
 }
 
-func getOptionsData(stock, accessToken, refreshToken, appKey, secretKey string) ([]Option, string, string, error) {
-	now := time.Now()
-	start := now.AddDate(0, 3, 0)
-	dateFormat := "2006-01-02"
-	startDate := start.Format(dateFormat)
-
-	end := now.AddDate(0, 9, 0)
-	endDate := end.Format(dateFormat)
-
-	optionsChainURL := fmt.Sprintf("https://api.schwabapi.com/marketdata/v1/chains?symbol=%s&includeUnderlyingQuote=true&range=NTM&strikeCount=10&fromDate=%s&toDate=%s", stock, startDate, endDate)
+// scan fans the stock list out across a worker pool and collects every
+// option contract returned by provider.
+func scan(ctx context.Context, provider marketdata.OptionsProvider, stockTickers []string) []marketdata.Option {
+	jobs := make(chan string, len(stockTickers))
+	results := make(chan []marketdata.Option, len(stockTickers))
 
-	req, err := http.NewRequest("GET", optionsChainURL, nil)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("error creating request for %s: %v", stock, err)
+	const numWorkers = 10
+	for i := 0; i < numWorkers; i++ {
+		go worker(ctx, jobs, results, provider)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
-
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("error making API call for %s: %v", stock, err)
+	for _, stock := range stockTickers {
+		jobs <- stock
 	}
-	defer res.Body.Close()
+	close(jobs)
 
-	if res.StatusCode == http.StatusUnauthorized {
-		// Token might be expired, try to refresh
-		newAccessToken, newRefreshToken, err := refreshTokens(refreshToken, appKey, secretKey)
-		if err != nil {
-			return nil, "", "", fmt.Errorf("error refreshing token: %v", err)
-		}
+	var options []marketdata.Option
+	for i := 0; i < len(stockTickers); i++ {
+		options = append(options, <-results...)
+	}
+	return options
+}
 
-		// Retry the request with the new access token
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", newAccessToken))
-		res, err = client.Do(req)
+func worker(ctx context.Context, jobs <-chan string, results chan<- []marketdata.Option, provider marketdata.OptionsProvider) {
+	for stock := range jobs {
+		chain, err := provider.GetOptionChain(ctx, stock,
+			marketdata.WithStrikeCount(10),
+			marketdata.WithRange(marketdata.RangeNTM),
+		)
 		if err != nil {
-			return nil, "", "", fmt.Errorf("error making API call with refreshed token for %s: %v", stock, err)
-		}
-		defer res.Body.Close()
-
-		if res.StatusCode != http.StatusOK {
-			return nil, "", "", fmt.Errorf("API call failed with status code %d after token refresh", res.StatusCode)
+			log.Printf("Error getting options data for %s: %v", stock, err)
+			continue
 		}
 
-		accessToken = newAccessToken
-		refreshToken = newRefreshToken
-	} else if res.StatusCode != http.StatusOK {
-		return nil, "", "", fmt.Errorf("API call failed with status code %d", res.StatusCode)
+		results <- chain.Options
 	}
-
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("could not read response body for %s: %v", stock, err)
-	}
-
-	var optionsChain OptionsChain
-	err = json.Unmarshal(resBody, &optionsChain)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("error unmarshaling JSON for %s: %v", stock, err)
-	}
-
-	options := []Option{}
-
-	optionTypeMaps := []map[string]map[string][]OptionContract{optionsChain.CallExpDateMap, optionsChain.PutExpDateMap}
-	for _, optionTypeMap := range optionTypeMaps {
-		for _, strikes := range optionTypeMap {
-			for _, contracts := range strikes {
-				if len(contracts) > 0 {
-					option := Option{
-						Symbol:                 stock,
-						Description:            contracts[0].Description,
-						ExchangeName:           contracts[0].ExchangeName,
-						LastStockPrice:         optionsChain.Underlying.Last,
-						stockPercentChange:     optionsChain.Underlying.PercentChange,
-						lastPrice:              contracts[0].Last,
-						fiftyTwoWeekHigh:       optionsChain.Underlying.FiftyTwoWeekHigh,
-						fiftyTwoWeekLow:        optionsChain.Underlying.FiftyTwoWeekLow,
-						optionType:             contracts[0].PutCall,
-						OptionSymbol:           contracts[0].Symbol,
-						Bid:                    contracts[0].Bid,
-						Ask:                    contracts[0].Ask,
-						Last:                   contracts[0].Last,
-						Mark:                   contracts[0].Mark,
-						BidSize:                contracts[0].BidSize,
-						AskSize:                contracts[0].AskSize,
-						BidAskSize:             contracts[0].BidAskSize,
-						LastSize:               contracts[0].LastSize,
-						HighPrice:              contracts[0].HighPrice,
-						LowPrice:               contracts[0].LowPrice,
-						OpenPrice:              contracts[0].OpenPrice,
-						ClosePrice:             contracts[0].ClosePrice,
-						TotalVolume:            contracts[0].TotalVolume,
-						NetChange:              contracts[0].NetChange,
-						Volatility:             contracts[0].Volatility,
-						Delta:                  contracts[0].Delta,
-						Gamma:                  contracts[0].Gamma,
-						Theta:                  contracts[0].Theta,
-						Vega:                   contracts[0].Vega,
-						Rho:                    contracts[0].Rho,
-						OpenInterest:           contracts[0].OpenInterest,
-						TimeValue:              contracts[0].TimeValue,
-						TheoreticalOptionValue: contracts[0].TheoreticalOptionValue,
-						TheoreticalVolatility:  contracts[0].TheoreticalVolatility,
-						StrikePrice:            contracts[0].StrikePrice,
-						ExpirationDate:         contracts[0].ExpirationDate,
-						DaysToExpiration:       contracts[0].DaysToExpiration,
-						LastTradingDay:         contracts[0].LastTradingDay,
-						PercentChange:          contracts[0].PercentChange,
-						MarkChange:             contracts[0].MarkChange,
-						MarkPercentChange:      contracts[0].MarkPercentChange,
-						IntrinsicValue:         contracts[0].IntrinsicValue,
-						ExtrinsicValue:         contracts[0].ExtrinsicValue,
-						InTheMoney:             contracts[0].InTheMoney,
-					}
-					if option.Volatility > 0 {
-						options = append(options, option)
-					}
-				}
-			}
-		}
-	}
-	return options, accessToken, refreshToken, nil
-}
-
-func refreshTokens(refreshToken, appKey, secretKey string) (string, string, error) {
-    tokenURL := "https://api.schwabapi.com/oauth2/v1/token"
-
-    data := url.Values{}
-    data.Set("grant_type", "refresh_token")
-    data.Set("refresh_token", refreshToken)
-
-    req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
-    if err != nil {
-        return "", "", fmt.Errorf("error creating refresh token request: %v", err)
-    }
-
-    // Set headers
-    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-    authHeader := base64.StdEncoding.EncodeToString([]byte(appKey + ":" + secretKey))
-    req.Header.Set("Authorization", "Basic "+authHeader)
-
-    client := &http.Client{}
-    resp, err := client.Do(req)
-    if err != nil {
-        return "", "", fmt.Errorf("error refreshing token: %v", err)
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK {
-        body, _ := io.ReadAll(resp.Body)
-        return "", "", fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, string(body))
-    }
-
-    var result struct {
-        AccessToken  string `json:"access_token"`
-        RefreshToken string `json:"refresh_token"`
-    }
-
-    err = json.NewDecoder(resp.Body).Decode(&result)
-    if err != nil {
-        return "", "", fmt.Errorf("error decoding refresh response: %v", err)
-    }
-
-    return result.AccessToken, result.RefreshToken, nil
 }
 
 func readStocksFile(filename string) ([]string, error) {
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+var csvHeader = []string{
+	"symbol", "option_symbol", "option_type", "strike_price", "expiration_date",
+	"days_to_expiration", "underlying_price", "bid", "ask", "mark", "last",
+	"volume", "open_interest", "volatility", "delta", "gamma", "theta", "vega", "rho",
+}
+
+func writeCSV(w io.Writer, options []marketdata.Option) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("export: writing CSV header: %w", err)
+	}
+
+	for _, o := range options {
+		record := []string{
+			o.Symbol,
+			o.OptionSymbol,
+			o.OptionType,
+			strconv.FormatFloat(o.StrikePrice, 'f', -1, 64),
+			o.ExpirationDate,
+			strconv.Itoa(o.DaysToExpiration),
+			strconv.FormatFloat(o.LastStockPrice, 'f', -1, 64),
+			strconv.FormatFloat(o.Bid, 'f', -1, 64),
+			strconv.FormatFloat(o.Ask, 'f', -1, 64),
+			strconv.FormatFloat(o.Mark, 'f', -1, 64),
+			strconv.FormatFloat(o.Last, 'f', -1, 64),
+			strconv.Itoa(o.TotalVolume),
+			strconv.Itoa(o.OpenInterest),
+			strconv.FormatFloat(o.Volatility, 'f', -1, 64),
+			strconv.FormatFloat(o.Delta, 'f', -1, 64),
+			strconv.FormatFloat(o.Gamma, 'f', -1, 64),
+			strconv.FormatFloat(o.Theta, 'f', -1, 64),
+			strconv.FormatFloat(o.Vega, 'f', -1, 64),
+			strconv.FormatFloat(o.Rho, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("export: writing CSV row for %s: %w", o.OptionSymbol, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,30 @@
+// Package export writes a scan snapshot to CSV or Parquet so it can be
+// loaded into downstream analysis tools without going through pkg/store.
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+// Format is an export file format selected by the --export flag.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+// Write exports options to w in the given format.
+func Write(w io.Writer, format Format, options []marketdata.Option) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, options)
+	case FormatParquet:
+		return writeParquet(w, options)
+	default:
+		return fmt.Errorf("export: unknown format %q", format)
+	}
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRow mirrors csvHeader's columns; struct tags drive the Parquet
+// schema via field names (parquet-go defaults to the lowercased Go field
+// name when no tag is given).
+type parquetRow struct {
+	Symbol           string  `parquet:"symbol"`
+	OptionSymbol     string  `parquet:"option_symbol"`
+	OptionType       string  `parquet:"option_type"`
+	StrikePrice      float64 `parquet:"strike_price"`
+	ExpirationDate   string  `parquet:"expiration_date"`
+	DaysToExpiration int     `parquet:"days_to_expiration"`
+	UnderlyingPrice  float64 `parquet:"underlying_price"`
+	Bid              float64 `parquet:"bid"`
+	Ask              float64 `parquet:"ask"`
+	Mark             float64 `parquet:"mark"`
+	Last             float64 `parquet:"last"`
+	Volume           int     `parquet:"volume"`
+	OpenInterest     int     `parquet:"open_interest"`
+	Volatility       float64 `parquet:"volatility"`
+	Delta            float64 `parquet:"delta"`
+	Gamma            float64 `parquet:"gamma"`
+	Theta            float64 `parquet:"theta"`
+	Vega             float64 `parquet:"vega"`
+	Rho              float64 `parquet:"rho"`
+}
+
+func writeParquet(w io.Writer, options []marketdata.Option) error {
+	writer := parquet.NewGenericWriter[parquetRow](w)
+
+	for _, o := range options {
+		row := parquetRow{
+			Symbol:           o.Symbol,
+			OptionSymbol:     o.OptionSymbol,
+			OptionType:       o.OptionType,
+			StrikePrice:      o.StrikePrice,
+			ExpirationDate:   o.ExpirationDate,
+			DaysToExpiration: o.DaysToExpiration,
+			UnderlyingPrice:  o.LastStockPrice,
+			Bid:              o.Bid,
+			Ask:              o.Ask,
+			Mark:             o.Mark,
+			Last:             o.Last,
+			Volume:           o.TotalVolume,
+			OpenInterest:     o.OpenInterest,
+			Volatility:       o.Volatility,
+			Delta:            o.Delta,
+			Gamma:            o.Gamma,
+			Theta:            o.Theta,
+			Vega:             o.Vega,
+			Rho:              o.Rho,
+		}
+		if _, err := writer.Write([]parquetRow{row}); err != nil {
+			return fmt.Errorf("export: writing parquet row for %s: %w", o.OptionSymbol, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("export: closing parquet writer: %w", err)
+	}
+	return nil
+}
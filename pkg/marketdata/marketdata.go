@@ -0,0 +1,105 @@
+// Package marketdata defines the provider-agnostic option chain types and the
+// OptionsProvider interface that scanning/ranking code is written against, so
+// it isn't hard-wired to any single broker's API.
+package marketdata
+
+import "context"
+
+// RangeType narrows an option chain request to strikes around the money.
+type RangeType string
+
+const (
+	RangeITM RangeType = "ITM"
+	RangeNTM RangeType = "NTM"
+	RangeOTM RangeType = "OTM"
+)
+
+// Option is a single option contract, normalized across providers.
+type Option struct {
+	Symbol                 string
+	Description            string
+	ExchangeName           string
+	LastStockPrice         float64
+	StockPercentChange     float64
+	FiftyTwoWeekHigh       float64
+	FiftyTwoWeekLow        float64
+	OptionType             string
+	OptionSymbol           string
+	Bid                    float64
+	Ask                    float64
+	Last                   float64
+	Mark                   float64
+	BidSize                int
+	AskSize                int
+	BidAskSize             string
+	LastSize               int
+	HighPrice              float64
+	LowPrice               float64
+	OpenPrice              float64
+	ClosePrice             float64
+	TotalVolume            int
+	NetChange              float64
+	Volatility             float64
+	Delta                  float64
+	Gamma                  float64
+	Theta                  float64
+	Vega                   float64
+	Rho                    float64
+	OpenInterest           int
+	TimeValue              float64
+	TheoreticalOptionValue float64
+	TheoreticalVolatility  float64
+	StrikePrice            float64
+	ExpirationDate         string
+	DaysToExpiration       int
+	LastTradingDay         int64
+	PercentChange          float64
+	MarkChange             float64
+	MarkPercentChange      float64
+	IntrinsicValue         float64
+	ExtrinsicValue         float64
+	InTheMoney             bool
+}
+
+// OptionsChain is the set of contracts returned for a single underlying.
+type OptionsChain struct {
+	Symbol  string
+	Options []Option
+}
+
+// ChainRequest configures a call to GetOptionChain. Providers apply whichever
+// fields they understand and ignore the rest.
+type ChainRequest struct {
+	StrikeCount int
+	Range       RangeType
+	FromDate    string
+	ToDate      string
+}
+
+// OptionalParameter mutates a ChainRequest; callers compose zero or more of
+// them when calling GetOptionChain instead of growing its positional
+// argument list.
+type OptionalParameter func(*ChainRequest)
+
+// WithStrikeCount limits the chain to n strikes centered on the money.
+func WithStrikeCount(n int) OptionalParameter {
+	return func(r *ChainRequest) { r.StrikeCount = n }
+}
+
+// WithRange narrows the chain to ITM, NTM, or OTM strikes.
+func WithRange(rt RangeType) OptionalParameter {
+	return func(r *ChainRequest) { r.Range = rt }
+}
+
+// WithDateRange limits the chain to expirations between from and to
+// (YYYY-MM-DD).
+func WithDateRange(from, to string) OptionalParameter {
+	return func(r *ChainRequest) { r.FromDate, r.ToDate = from, to }
+}
+
+// OptionsProvider is satisfied by any market-data source capable of serving
+// option chains, whether that's Schwab, Tradier, Polygon, or a mock used in
+// tests.
+type OptionsProvider interface {
+	GetOptionChain(ctx context.Context, symbol string, opts ...OptionalParameter) (*OptionsChain, error)
+}
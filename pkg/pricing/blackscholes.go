@@ -0,0 +1,98 @@
+// Package pricing computes theoretical option prices, greeks, and implied
+// volatility from first principles, so the scanner isn't fully dependent on
+// Schwab's Volatility/TheoreticalOptionValue fields, which are sometimes 0
+// or stale.
+package pricing
+
+import "math"
+
+// Inputs are the Black-Scholes-Merton parameters for a single European-style
+// equity option with continuous dividend yield q.
+type Inputs struct {
+	Spot     float64 // S
+	Strike   float64 // K
+	Rate     float64 // r, annualized risk-free rate
+	Dividend float64 // q, annualized continuous dividend yield
+	Vol      float64 // sigma, annualized volatility
+	T        float64 // years to expiration
+	IsCall   bool
+}
+
+// Greeks are the standard closed-form sensitivities of a Black-Scholes price
+// to its inputs.
+type Greeks struct {
+	Delta float64
+	Gamma float64
+	Theta float64
+	Vega  float64
+	Rho   float64
+}
+
+func (in Inputs) d1() float64 {
+	return (math.Log(in.Spot/in.Strike) + (in.Rate-in.Dividend+0.5*in.Vol*in.Vol)*in.T) / (in.Vol * math.Sqrt(in.T))
+}
+
+func (in Inputs) d2(d1 float64) float64 {
+	return d1 - in.Vol*math.Sqrt(in.T)
+}
+
+// Price returns the theoretical Black-Scholes price of the option described
+// by in. Calls are priced directly; puts are derived via put-call parity.
+func Price(in Inputs) float64 {
+	d1 := in.d1()
+	d2 := in.d2(d1)
+
+	call := in.Spot*math.Exp(-in.Dividend*in.T)*normCDF(d1) - in.Strike*math.Exp(-in.Rate*in.T)*normCDF(d2)
+	if in.IsCall {
+		return call
+	}
+
+	// Put-call parity: C - P = S*e^(-qT) - K*e^(-rT)
+	return call - in.Spot*math.Exp(-in.Dividend*in.T) + in.Strike*math.Exp(-in.Rate*in.T)
+}
+
+// ComputeGreeks returns the standard closed-form greeks for the option
+// described by in.
+func ComputeGreeks(in Inputs) Greeks {
+	d1 := in.d1()
+	d2 := in.d2(d1)
+
+	sqrtT := math.Sqrt(in.T)
+	discountedSpot := in.Spot * math.Exp(-in.Dividend*in.T)
+	discountedStrike := in.Strike * math.Exp(-in.Rate*in.T)
+
+	gamma := normPDF(d1) * math.Exp(-in.Dividend*in.T) / (in.Spot * in.Vol * sqrtT)
+	vega := discountedSpot * normPDF(d1) * sqrtT
+
+	if in.IsCall {
+		return Greeks{
+			Delta: math.Exp(-in.Dividend*in.T) * normCDF(d1),
+			Gamma: gamma,
+			Theta: -discountedSpot*normPDF(d1)*in.Vol/(2*sqrtT) -
+				in.Rate*discountedStrike*normCDF(d2) +
+				in.Dividend*discountedSpot*normCDF(d1),
+			Vega: vega,
+			Rho:  in.T * discountedStrike * normCDF(d2),
+		}
+	}
+
+	return Greeks{
+		Delta: math.Exp(-in.Dividend*in.T) * (normCDF(d1) - 1),
+		Gamma: gamma,
+		Theta: -discountedSpot*normPDF(d1)*in.Vol/(2*sqrtT) +
+			in.Rate*discountedStrike*normCDF(-d2) -
+			in.Dividend*discountedSpot*normCDF(-d1),
+		Vega: vega,
+		Rho:  -in.T * discountedStrike * normCDF(-d2),
+	}
+}
+
+// normCDF is the standard normal cumulative distribution function.
+func normCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// normPDF is the standard normal probability density function.
+func normPDF(x float64) float64 {
+	return math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+// hullExample is the worked example from Hull, Options, Futures, and Other
+// Derivatives: S=42, K=40, r=10%, sigma=20%, T=0.5 years, no dividend. Hull
+// gives a call price of 4.76 and a put price of 0.81.
+var hullExample = Inputs{
+	Spot:   42,
+	Strike: 40,
+	Rate:   0.10,
+	Vol:    0.20,
+	T:      0.5,
+	IsCall: true,
+}
+
+func TestPriceCallMatchesHullExample(t *testing.T) {
+	got := Price(hullExample)
+	want := 4.7594
+	if math.Abs(got-want) > 1e-3 {
+		t.Errorf("Price(call) = %v, want %v", got, want)
+	}
+}
+
+func TestPricePutMatchesHullExample(t *testing.T) {
+	in := hullExample
+	in.IsCall = false
+	got := Price(in)
+	want := 0.8086
+	if math.Abs(got-want) > 1e-3 {
+		t.Errorf("Price(put) = %v, want %v", got, want)
+	}
+}
+
+func TestPricePutCallParity(t *testing.T) {
+	call := hullExample
+	put := hullExample
+	put.IsCall = false
+
+	c, p := Price(call), Price(put)
+	gotDiff := c - p
+	wantDiff := call.Spot*math.Exp(-call.Dividend*call.T) - call.Strike*math.Exp(-call.Rate*call.T)
+	if math.Abs(gotDiff-wantDiff) > 1e-9 {
+		t.Errorf("C - P = %v, want S*e^-qT - K*e^-rT = %v", gotDiff, wantDiff)
+	}
+}
+
+func TestComputeGreeksMatchesHullExample(t *testing.T) {
+	greeks := ComputeGreeks(hullExample)
+
+	wantDelta := 0.7791
+	if math.Abs(greeks.Delta-wantDelta) > 1e-3 {
+		t.Errorf("Delta = %v, want %v", greeks.Delta, wantDelta)
+	}
+
+	wantVega := 8.8134
+	if math.Abs(greeks.Vega-wantVega) > 1e-3 {
+		t.Errorf("Vega = %v, want %v", greeks.Vega, wantVega)
+	}
+
+	wantGamma := 0.04996
+	if math.Abs(greeks.Gamma-wantGamma) > 1e-4 {
+		t.Errorf("Gamma = %v, want %v", greeks.Gamma, wantGamma)
+	}
+}
+
+func TestComputeGreeksPutCallDeltaRelation(t *testing.T) {
+	call := ComputeGreeks(hullExample)
+
+	put := hullExample
+	put.IsCall = false
+	putGreeks := ComputeGreeks(put)
+
+	// Delta_call - Delta_put = e^-qT for any European option.
+	want := math.Exp(-hullExample.Dividend * hullExample.T)
+	got := call.Delta - putGreeks.Delta
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Delta_call - Delta_put = %v, want %v", got, want)
+	}
+}
+
+func TestImpliedVolRoundTripsThroughPrice(t *testing.T) {
+	price := Price(hullExample)
+
+	iv, err := ImpliedVol(price, hullExample)
+	if err != nil {
+		t.Fatalf("ImpliedVol: %v", err)
+	}
+	if math.Abs(iv-hullExample.Vol) > 1e-4 {
+		t.Errorf("ImpliedVol = %v, want %v", iv, hullExample.Vol)
+	}
+}
@@ -0,0 +1,129 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	ivLowerBound = 1e-6
+	ivUpperBound = 5.0
+	ivTolerance  = 1e-6
+	ivMaxIter    = 100
+)
+
+// ImpliedVol inverts price -> sigma with Brent's method on
+// [ivLowerBound, ivUpperBound] using targetPrice (typically the market mid)
+// as the root-finding target. in.Vol is ignored; everything else must be
+// populated. Falls back to Newton-Raphson, seeded by the Manaster-Koehler
+// initial guess, if Brent's method fails to bracket a root.
+func ImpliedVol(targetPrice float64, in Inputs) (float64, error) {
+	f := func(sigma float64) float64 {
+		in.Vol = sigma
+		return Price(in) - targetPrice
+	}
+
+	if iv, err := brent(f, ivLowerBound, ivUpperBound, ivTolerance, ivMaxIter); err == nil {
+		return iv, nil
+	}
+
+	return newtonRaphson(targetPrice, in)
+}
+
+// brent finds a root of f within [lo, hi] to within tol, using Brent's
+// method. Returns an error if f doesn't change sign across the bracket.
+func brent(f func(float64) float64, lo, hi, tol float64, maxIter int) (float64, error) {
+	fLo, fHi := f(lo), f(hi)
+	if fLo*fHi > 0 {
+		return 0, fmt.Errorf("pricing: root not bracketed in [%v, %v]", lo, hi)
+	}
+
+	a, b := lo, hi
+	fa, fb := fLo, fHi
+	if math.Abs(fa) < math.Abs(fb) {
+		a, b = b, a
+		fa, fb = fb, fa
+	}
+
+	c, fc := a, fa
+	mflag := true
+	var d float64
+
+	for i := 0; i < maxIter; i++ {
+		if math.Abs(b-a) < tol {
+			return b, nil
+		}
+
+		var s float64
+		if fa != fc && fb != fc {
+			// Inverse quadratic interpolation.
+			s = a*fb*fc/((fa-fb)*(fa-fc)) +
+				b*fa*fc/((fb-fa)*(fb-fc)) +
+				c*fa*fb/((fc-fa)*(fc-fb))
+		} else {
+			// Secant method.
+			s = b - fb*(b-a)/(fb-fa)
+		}
+
+		midpoint := (3*a + b) / 4
+		useBisection := (s-midpoint)*(b-midpoint) > 0 ||
+			(mflag && math.Abs(s-b) >= math.Abs(b-c)/2) ||
+			(!mflag && math.Abs(s-b) >= math.Abs(c-d)/2)
+
+		if useBisection {
+			s = (a + b) / 2
+			mflag = true
+		} else {
+			mflag = false
+		}
+
+		fs := f(s)
+		d, c, fc = c, b, fb
+
+		if fa*fs < 0 {
+			b, fb = s, fs
+		} else {
+			a, fa = s, fs
+		}
+
+		if math.Abs(fa) < math.Abs(fb) {
+			a, b = b, a
+			fa, fb = fb, fa
+		}
+	}
+
+	return b, nil
+}
+
+// newtonRaphson falls back to Newton-Raphson with the Manaster-Koehler
+// initial guess sigma0 = sqrt(|ln(S/K) + rT| * 2/T).
+func newtonRaphson(targetPrice float64, in Inputs) (float64, error) {
+	sigma := math.Sqrt(math.Abs(math.Log(in.Spot/in.Strike)+in.Rate*in.T) * 2 / in.T)
+	if sigma <= 0 || math.IsNaN(sigma) {
+		sigma = 0.5
+	}
+
+	for i := 0; i < ivMaxIter; i++ {
+		in.Vol = sigma
+		price := Price(in)
+		vega := ComputeGreeks(in).Vega
+
+		diff := price - targetPrice
+		if math.Abs(diff) < ivTolerance {
+			return sigma, nil
+		}
+		if vega < 1e-8 {
+			return 0, fmt.Errorf("pricing: newton-raphson stalled, vega too small near sigma=%v", sigma)
+		}
+
+		sigma -= diff / vega
+		if sigma <= ivLowerBound {
+			sigma = ivLowerBound
+		}
+		if sigma >= ivUpperBound {
+			sigma = ivUpperBound
+		}
+	}
+
+	return 0, fmt.Errorf("pricing: newton-raphson failed to converge after %d iterations", ivMaxIter)
+}
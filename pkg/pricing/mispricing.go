@@ -0,0 +1,82 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+const daysPerYear = 365.25
+
+// Evaluation is the theoretical price, implied volatility, and greeks
+// pricing computed for a single option, alongside how far the market mid
+// sits from that theoretical price.
+type Evaluation struct {
+	Theoretical float64
+	IV          float64
+	Greeks      Greeks
+	// Mispricing is MarketMid - Theoretical: positive means the option is
+	// trading rich to the model, negative means cheap.
+	Mispricing float64
+}
+
+// Score ranks evaluations by how exploitable the mispricing is, scaled by
+// vega so two options that are equally mispriced in dollar terms but have
+// different sensitivity to volatility are ordered correctly.
+func (e Evaluation) Score() float64 {
+	if e.Greeks.Vega == 0 {
+		return 0
+	}
+	return abs(e.Mispricing) / abs(e.Greeks.Vega)
+}
+
+// Evaluate prices opt under Black-Scholes using riskFreeRate and the
+// continuous dividend yield from dividendSource, computing implied
+// volatility from the market mid ((bid+ask)/2) rather than trusting
+// Schwab's possibly-stale Volatility field.
+func Evaluate(ctx context.Context, opt marketdata.Option, rateSource RateSource, dividendSource DividendSource) (Evaluation, error) {
+	if opt.DaysToExpiration <= 0 {
+		return Evaluation{}, fmt.Errorf("pricing: option %s has no time to expiration", opt.OptionSymbol)
+	}
+
+	rate, err := rateSource.Rate(ctx)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("pricing: error getting risk-free rate: %w", err)
+	}
+
+	dividend, err := dividendSource.DividendYield(ctx)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("pricing: error getting dividend yield: %w", err)
+	}
+
+	mid := (opt.Bid + opt.Ask) / 2
+	in := Inputs{
+		Spot:     opt.LastStockPrice,
+		Strike:   opt.StrikePrice,
+		Rate:     rate,
+		Dividend: dividend,
+		T:        float64(opt.DaysToExpiration) / daysPerYear,
+		IsCall:   opt.OptionType == "CALL",
+	}
+
+	iv, err := ImpliedVol(mid, in)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("pricing: error computing implied vol for %s: %w", opt.OptionSymbol, err)
+	}
+
+	in.Vol = iv
+	return Evaluation{
+		Theoretical: Price(in),
+		IV:          iv,
+		Greeks:      ComputeGreeks(in),
+		Mispricing:  mid - Price(in),
+	}, nil
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,104 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// treasuryYieldURL is a public FRED series for the 13-week Treasury bill
+// discount rate, used as a proxy for the risk-free rate.
+const treasuryYieldURL = "https://api.stlouisfed.org/fred/series/observations?series_id=DTB3&file_type=json"
+
+// RateSource supplies the risk-free rate used to price options.
+type RateSource interface {
+	Rate(ctx context.Context) (float64, error)
+}
+
+// FlatRate is a RateSource that always returns the same configured rate.
+type FlatRate float64
+
+// Rate implements RateSource.
+func (f FlatRate) Rate(ctx context.Context) (float64, error) {
+	return float64(f), nil
+}
+
+// DividendSource supplies the continuous dividend yield used to price
+// options.
+type DividendSource interface {
+	DividendYield(ctx context.Context) (float64, error)
+}
+
+// FlatDividend is a DividendSource that always returns the same configured
+// yield.
+type FlatDividend float64
+
+// DividendYield implements DividendSource.
+func (f FlatDividend) DividendYield(ctx context.Context) (float64, error) {
+	return float64(f), nil
+}
+
+// TreasuryRate is a RateSource that fetches the latest short-term Treasury
+// yield once per run and caches it for the lifetime of the process.
+type TreasuryRate struct {
+	apiKey string
+
+	once  sync.Once
+	rate  float64
+	fetch error
+}
+
+// NewTreasuryRate returns a RateSource backed by the FRED 13-week Treasury
+// bill series, fetched lazily on first use.
+func NewTreasuryRate(apiKey string) *TreasuryRate {
+	return &TreasuryRate{apiKey: apiKey}
+}
+
+// Rate implements RateSource, fetching the yield on first call and reusing
+// it for every subsequent call.
+func (t *TreasuryRate) Rate(ctx context.Context) (float64, error) {
+	t.once.Do(func() {
+		t.rate, t.fetch = t.fetchLatestYield(ctx)
+	})
+	return t.rate, t.fetch
+}
+
+func (t *TreasuryRate) fetchLatestYield(ctx context.Context) (float64, error) {
+	url := fmt.Sprintf("%s&api_key=%s&sort_order=desc&limit=1", treasuryYieldURL, t.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("pricing: error creating treasury yield request: %w", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("pricing: error fetching treasury yield: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("pricing: treasury yield request failed with status %d", res.StatusCode)
+	}
+
+	var parsed struct {
+		Observations []struct {
+			Value string `json:"value"`
+		} `json:"observations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return 0, fmt.Errorf("pricing: error decoding treasury yield response: %w", err)
+	}
+	if len(parsed.Observations) == 0 {
+		return 0, fmt.Errorf("pricing: treasury yield response had no observations")
+	}
+
+	var percent float64
+	if _, err := fmt.Sscanf(parsed.Observations[0].Value, "%f", &percent); err != nil {
+		return 0, fmt.Errorf("pricing: error parsing treasury yield value: %w", err)
+	}
+
+	return percent / 100, nil
+}
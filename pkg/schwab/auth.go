@@ -0,0 +1,256 @@
+package schwab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authURL  = "https://api.schwabapi.com/v1/oauth/authorize"
+	tokenURL = "https://api.schwabapi.com/v1/oauth/token"
+
+	// defaultTokenFile is the path, relative to the working directory,
+	// that persisted tokens are read from and written to.
+	defaultTokenFile = "tokens.json"
+
+	// tokenRefreshGap is how often RefreshLoop proactively renews the
+	// access token, well inside Schwab's 30 minute expiry.
+	tokenRefreshGap = 25 * time.Minute
+)
+
+// TokenSource holds the current Schwab OAuth tokens and keeps them in sync
+// with a file on disk so the background refresh loop and worker goroutines
+// always see the latest access/refresh token pair.
+type TokenSource struct {
+	mu           sync.RWMutex
+	accessToken  string
+	refreshToken string
+	appKey       string
+	secretKey    string
+	path         string
+}
+
+// storedTokens is the on-disk representation of a TokenSource.
+type storedTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// NewTokenSource wraps an initial token pair in a TokenSource, ready to be
+// shared across workers and the background refresh goroutine. Tokens are
+// persisted to defaultTokenFile.
+func NewTokenSource(appKey, secretKey, accessToken, refreshToken string) *TokenSource {
+	return &TokenSource{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		appKey:       appKey,
+		secretKey:    secretKey,
+		path:         defaultTokenFile,
+	}
+}
+
+// LoadTokenSource reads a previously persisted token pair from disk. It
+// returns ok == false if no usable token file exists yet.
+func LoadTokenSource(appKey, secretKey string) (ts *TokenSource, ok bool) {
+	data, err := os.ReadFile(defaultTokenFile)
+	if err != nil {
+		return nil, false
+	}
+
+	var stored storedTokens
+	if err := json.Unmarshal(data, &stored); err != nil {
+		log.Printf("Error parsing %s, ignoring: %v", defaultTokenFile, err)
+		return nil, false
+	}
+
+	if stored.AccessToken == "" || stored.RefreshToken == "" {
+		return nil, false
+	}
+
+	return NewTokenSource(appKey, secretKey, stored.AccessToken, stored.RefreshToken), true
+}
+
+// Tokens returns the current access and refresh token.
+func (t *TokenSource) Tokens() (accessToken, refreshToken string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.accessToken, t.refreshToken
+}
+
+// Set updates the current tokens and persists them to disk.
+func (t *TokenSource) Set(accessToken, refreshToken string) error {
+	t.mu.Lock()
+	t.accessToken = accessToken
+	t.refreshToken = refreshToken
+	t.mu.Unlock()
+	return t.save()
+}
+
+// Refresh exchanges the current refresh token for a new token pair and
+// persists the result. It's safe to call concurrently from multiple workers.
+func (t *TokenSource) Refresh() (accessToken, refreshToken string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	accessToken, refreshToken, err = refreshTokens(t.refreshToken, t.appKey, t.secretKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	t.accessToken = accessToken
+	t.refreshToken = refreshToken
+
+	if err := t.saveLocked(); err != nil {
+		log.Printf("Error persisting refreshed tokens: %v", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// RefreshLoop proactively refreshes the access token every tokenRefreshGap so
+// long-running scans never stall on a reactive 401. It runs until ctx is
+// cancelled.
+func (t *TokenSource) RefreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(tokenRefreshGap)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, _, err := t.Refresh(); err != nil {
+				log.Printf("Background token refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+func (t *TokenSource) save() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.saveLocked()
+}
+
+// saveLocked writes the current tokens to disk. Callers must hold t.mu.
+func (t *TokenSource) saveLocked() error {
+	data, err := json.MarshalIndent(storedTokens{
+		AccessToken:  t.accessToken,
+		RefreshToken: t.refreshToken,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling tokens: %w", err)
+	}
+	return os.WriteFile(t.path, data, 0600)
+}
+
+// GetInitialToken walks the user through the interactive OAuth authorization
+// flow and exchanges the resulting code for an initial token pair.
+func GetInitialToken(appKey, secretKey string) (string, string, error) {
+	redirectURL := "https://127.0.0.1"
+
+	authCodeURL := fmt.Sprintf("%s?client_id=%s&redirect_uri=%s",
+		authURL, appKey, redirectURL)
+
+	fmt.Printf("Visit this URL to authorize the application: %v\n", authCodeURL)
+	fmt.Println("After authorization, you will be redirected. Copy and paste the ENTIRE redirected URL here:")
+
+	var redirectURIWithCode string
+	fmt.Scanln(&redirectURIWithCode)
+
+	parsedURL, err := url.Parse(redirectURIWithCode)
+	if err != nil {
+		return "", "", fmt.Errorf("couldn't parse redirect URI: %v", err)
+	}
+	code := parsedURL.Query().Get("code")
+	if code == "" {
+		return "", "", fmt.Errorf("no code found in redirect URI")
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", redirectURL)
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", "", fmt.Errorf("error creating token request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	authHeader := base64.StdEncoding.EncodeToString([]byte(appKey + ":" + secretKey))
+	req.Header.Set("Authorization", "Basic "+authHeader)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("error exchanging code for token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("error decoding token response: %v", err)
+	}
+
+	return result.AccessToken, result.RefreshToken, nil
+}
+
+func refreshTokens(refreshToken, appKey, secretKey string) (string, string, error) {
+	tokenURL := "https://api.schwabapi.com/oauth2/v1/token"
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", "", fmt.Errorf("error creating refresh token request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	authHeader := base64.StdEncoding.EncodeToString([]byte(appKey + ":" + secretKey))
+	req.Header.Set("Authorization", "Basic "+authHeader)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("error refreshing token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("error decoding refresh response: %v", err)
+	}
+
+	return result.AccessToken, result.RefreshToken, nil
+}
@@ -0,0 +1,200 @@
+package schwab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+const chainURL = "https://api.schwabapi.com/marketdata/v1/chains"
+
+type underlying struct {
+	PercentChange    float64 `json:"percentChange"`
+	Last             float64 `json:"last"`
+	FiftyTwoWeekHigh float64 `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow  float64 `json:"fiftyTwoWeekLow"`
+}
+
+type optionContract struct {
+	PutCall                string  `json:"putCall"`
+	Symbol                 string  `json:"symbol"`
+	Description            string  `json:"description"`
+	ExchangeName           string  `json:"exchangeName"`
+	Bid                    float64 `json:"bid"`
+	Ask                    float64 `json:"ask"`
+	Last                   float64 `json:"last"`
+	Mark                   float64 `json:"mark"`
+	BidSize                int     `json:"bidSize"`
+	AskSize                int     `json:"askSize"`
+	BidAskSize             string  `json:"bidAskSize"`
+	LastSize               int     `json:"lastSize"`
+	HighPrice              float64 `json:"highPrice"`
+	LowPrice               float64 `json:"lowPrice"`
+	OpenPrice              float64 `json:"openPrice"`
+	ClosePrice             float64 `json:"closePrice"`
+	TotalVolume            int     `json:"totalVolume"`
+	NetChange              float64 `json:"netChange"`
+	Volatility             float64 `json:"volatility"`
+	Delta                  float64 `json:"delta"`
+	Gamma                  float64 `json:"gamma"`
+	Theta                  float64 `json:"theta"`
+	Vega                   float64 `json:"vega"`
+	Rho                    float64 `json:"rho"`
+	OpenInterest           int     `json:"openInterest"`
+	TimeValue              float64 `json:"timeValue"`
+	TheoreticalOptionValue float64 `json:"theoreticalOptionValue"`
+	TheoreticalVolatility  float64 `json:"theoreticalVolatility"`
+	StrikePrice            float64 `json:"strikePrice"`
+	ExpirationDate         string  `json:"expirationDate"`
+	DaysToExpiration       int     `json:"daysToExpiration"`
+	LastTradingDay         int64   `json:"lastTradingDay"`
+	PercentChange          float64 `json:"percentChange"`
+	MarkChange             float64 `json:"markChange"`
+	MarkPercentChange      float64 `json:"markPercentChange"`
+	IntrinsicValue         float64 `json:"intrinsicValue"`
+	ExtrinsicValue         float64 `json:"extrinsicValue"`
+	InTheMoney             bool    `json:"inTheMoney"`
+}
+
+type rawOptionsChain struct {
+	Symbol         string                                 `json:"symbol"`
+	Underlying     underlying                             `json:"underlying"`
+	CallExpDateMap map[string]map[string][]optionContract `json:"callExpDateMap"`
+	PutExpDateMap  map[string]map[string][]optionContract `json:"putExpDateMap"`
+}
+
+// fetchChain issues the HTTP request for symbol's option chain, transparently
+// refreshing the access token and retrying once on a 401.
+func (c *Client) fetchChain(ctx context.Context, symbol string, chainReq marketdata.ChainRequest) (*rawOptionsChain, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %v", err)
+	}
+
+	if chainReq.FromDate == "" || chainReq.ToDate == "" {
+		now := time.Now()
+		chainReq.FromDate = now.AddDate(0, 3, 0).Format("2006-01-02")
+		chainReq.ToDate = now.AddDate(0, 9, 0).Format("2006-01-02")
+	}
+
+	url := fmt.Sprintf("%s?symbol=%s&includeUnderlyingQuote=true&range=%s&strikeCount=%d&fromDate=%s&toDate=%s",
+		chainURL, symbol, chainReq.Range, chainReq.StrikeCount, chainReq.FromDate, chainReq.ToDate)
+
+	accessToken, _ := c.tokens.Tokens()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %s: %v", symbol, err)
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making API call for %s: %v", symbol, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		newAccessToken, _, err := c.tokens.Refresh()
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing token: %v", err)
+		}
+
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", newAccessToken))
+		res, err = c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error making API call with refreshed token for %s: %v", symbol, err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("API call failed with status code %d after token refresh", res.StatusCode)
+		}
+	} else if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API call failed with status code %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body for %s: %v", symbol, err)
+	}
+
+	var chain rawOptionsChain
+	if err := json.Unmarshal(resBody, &chain); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON for %s: %v", symbol, err)
+	}
+
+	return &chain, nil
+}
+
+// toOptionsChain flattens Schwab's nested expiration/strike maps into the
+// provider-agnostic marketdata.OptionsChain shape.
+func toOptionsChain(symbol string, raw *rawOptionsChain) *marketdata.OptionsChain {
+	chain := &marketdata.OptionsChain{Symbol: symbol}
+
+	expDateMaps := []map[string]map[string][]optionContract{raw.CallExpDateMap, raw.PutExpDateMap}
+	for _, expDateMap := range expDateMaps {
+		for _, strikes := range expDateMap {
+			for _, contracts := range strikes {
+				if len(contracts) == 0 {
+					continue
+				}
+				c := contracts[0]
+				option := marketdata.Option{
+					Symbol:                 symbol,
+					Description:            c.Description,
+					ExchangeName:           c.ExchangeName,
+					LastStockPrice:         raw.Underlying.Last,
+					StockPercentChange:     raw.Underlying.PercentChange,
+					FiftyTwoWeekHigh:       raw.Underlying.FiftyTwoWeekHigh,
+					FiftyTwoWeekLow:        raw.Underlying.FiftyTwoWeekLow,
+					OptionType:             c.PutCall,
+					OptionSymbol:           c.Symbol,
+					Bid:                    c.Bid,
+					Ask:                    c.Ask,
+					Last:                   c.Last,
+					Mark:                   c.Mark,
+					BidSize:                c.BidSize,
+					AskSize:                c.AskSize,
+					BidAskSize:             c.BidAskSize,
+					LastSize:               c.LastSize,
+					HighPrice:              c.HighPrice,
+					LowPrice:               c.LowPrice,
+					OpenPrice:              c.OpenPrice,
+					ClosePrice:             c.ClosePrice,
+					TotalVolume:            c.TotalVolume,
+					NetChange:              c.NetChange,
+					Volatility:             c.Volatility,
+					Delta:                  c.Delta,
+					Gamma:                  c.Gamma,
+					Theta:                  c.Theta,
+					Vega:                   c.Vega,
+					Rho:                    c.Rho,
+					OpenInterest:           c.OpenInterest,
+					TimeValue:              c.TimeValue,
+					TheoreticalOptionValue: c.TheoreticalOptionValue,
+					TheoreticalVolatility:  c.TheoreticalVolatility,
+					StrikePrice:            c.StrikePrice,
+					ExpirationDate:         c.ExpirationDate,
+					DaysToExpiration:       c.DaysToExpiration,
+					LastTradingDay:         c.LastTradingDay,
+					PercentChange:          c.PercentChange,
+					MarkChange:             c.MarkChange,
+					MarkPercentChange:      c.MarkPercentChange,
+					IntrinsicValue:         c.IntrinsicValue,
+					ExtrinsicValue:         c.ExtrinsicValue,
+					InTheMoney:             c.InTheMoney,
+				}
+				if option.Volatility > 0 {
+					chain.Options = append(chain.Options, option)
+				}
+			}
+		}
+	}
+
+	return chain
+}
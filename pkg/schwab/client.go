@@ -0,0 +1,92 @@
+// Package schwab implements marketdata.OptionsProvider against the Schwab
+// Trader API: OAuth token management, a rate-limited HTTP client, and option
+// chain retrieval.
+package schwab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+	"golang.org/x/time/rate"
+)
+
+// defaultRequestsPerMinute matches Schwab's documented 120 requests/minute
+// ceiling. rate.Limit is expressed in events per second, so the limiter
+// below divides this by 60 rather than using it directly.
+const defaultRequestsPerMinute = 120
+
+// Config holds the credentials needed to authenticate against Schwab.
+type Config struct {
+	AppKey    string
+	SecretKey string
+}
+
+// Client is a rate-limited, auto-refreshing Schwab API client. It satisfies
+// marketdata.OptionsProvider.
+type Client struct {
+	cfg        Config
+	tokens     *TokenSource
+	httpClient *http.Client
+	limiter    *rate.Limiter
+}
+
+// New returns a Client ready to make requests, loading a persisted token
+// pair from disk if one exists and otherwise running the interactive OAuth
+// flow once.
+func New(cfg Config) (*Client, error) {
+	if cfg.AppKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("schwab: AppKey and SecretKey are required")
+	}
+
+	tokens, ok := LoadTokenSource(cfg.AppKey, cfg.SecretKey)
+	if !ok {
+		accessToken, refreshToken, err := GetInitialToken(cfg.AppKey, cfg.SecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("schwab: error getting initial token: %w", err)
+		}
+		tokens = NewTokenSource(cfg.AppKey, cfg.SecretKey, accessToken, refreshToken)
+		if err := tokens.Set(accessToken, refreshToken); err != nil {
+			return nil, fmt.Errorf("schwab: error persisting initial tokens: %w", err)
+		}
+	}
+
+	return &Client{
+		cfg:        cfg,
+		tokens:     tokens,
+		httpClient: &http.Client{},
+		limiter:    rate.NewLimiter(rate.Limit(defaultRequestsPerMinute)/60, defaultRequestsPerMinute),
+	}, nil
+}
+
+// Tokens returns the client's current access and refresh token, e.g. for
+// authenticating a separate connection such as the streamer WebSocket.
+func (c *Client) Tokens() (accessToken, refreshToken string) {
+	return c.tokens.Tokens()
+}
+
+// StartTokenRefresh launches the background goroutine that proactively
+// refreshes the access token until ctx is cancelled.
+func (c *Client) StartTokenRefresh(ctx context.Context) {
+	go c.tokens.RefreshLoop(ctx)
+}
+
+// GetOptionChain fetches an option chain for symbol, applying any optional
+// parameters (strike count, date range, range type) to the request.
+func (c *Client) GetOptionChain(ctx context.Context, symbol string, opts ...marketdata.OptionalParameter) (*marketdata.OptionsChain, error) {
+	req := marketdata.ChainRequest{
+		StrikeCount: 10,
+		Range:       marketdata.RangeNTM,
+	}
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	raw, err := c.fetchChain(ctx, symbol, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOptionsChain(symbol, raw), nil
+}
@@ -0,0 +1,88 @@
+package schwab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const userPreferenceURL = "https://api.schwabapi.com/trader/v1/userPreference"
+
+// StreamerInfo carries the connection details the streamer endpoint needs:
+// the WebSocket URL plus the customer/correlation IDs Schwab ties to this
+// account.
+type StreamerInfo struct {
+	StreamerURL string
+	CustomerID  string
+	CorrelID    string
+	Channel     string
+	FunctionID  string
+}
+
+// StreamerInfo fetches the account's streamer connection details from
+// Schwab's user preference endpoint, refreshing the access token on a 401
+// the same way GetOptionChain does.
+func (c *Client) StreamerInfo(ctx context.Context) (*StreamerInfo, error) {
+	accessToken, _ := c.tokens.Tokens()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", userPreferenceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user preference request: %v", err)
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making user preference call: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		newAccessToken, _, err := c.tokens.Refresh()
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing token: %v", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", newAccessToken))
+		res, err = c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error making user preference call with refreshed token: %v", err)
+		}
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user preference call failed with status code %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read user preference response: %v", err)
+	}
+
+	var parsed struct {
+		StreamerInfo []struct {
+			StreamerSocketURL      string `json:"streamerSocketUrl"`
+			SchwabClientCustomerID string `json:"schwabClientCustomerId"`
+			SchwabClientCorrelID   string `json:"schwabClientCorrelId"`
+			SchwabClientChannel    string `json:"schwabClientChannel"`
+			SchwabClientFunctionID string `json:"schwabClientFunctionId"`
+		} `json:"streamerInfo"`
+	}
+	if err := json.Unmarshal(resBody, &parsed); err != nil {
+		return nil, fmt.Errorf("error unmarshaling user preference response: %v", err)
+	}
+	if len(parsed.StreamerInfo) == 0 {
+		return nil, fmt.Errorf("user preference response had no streamerInfo")
+	}
+
+	info := parsed.StreamerInfo[0]
+	return &StreamerInfo{
+		StreamerURL: info.StreamerSocketURL,
+		CustomerID:  info.SchwabClientCustomerID,
+		CorrelID:    info.SchwabClientCorrelID,
+		Channel:     info.SchwabClientChannel,
+		FunctionID:  info.SchwabClientFunctionID,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// migration is one forward-only schema change, applied in order and
+// recorded in schema_migrations. Modeled on rockhopper-style migration
+// runners: a flat, ordered list of plain SQL statements rather than a
+// framework with up/down pairs, since this store never needs to roll back.
+type migration struct {
+	version int
+	sql     string
+}
+
+var migrations = []migration{
+	{
+		version: 1,
+		sql: `
+			CREATE TABLE option_snapshots (
+				scan_time                TEXT    NOT NULL,
+				option_symbol            TEXT    NOT NULL,
+				symbol                   TEXT    NOT NULL,
+				option_type              TEXT    NOT NULL,
+				strike_price             REAL    NOT NULL,
+				expiration_date          TEXT    NOT NULL,
+				days_to_expiration       INTEGER NOT NULL,
+				underlying_price         REAL    NOT NULL,
+				bid                      REAL    NOT NULL,
+				ask                      REAL    NOT NULL,
+				mark                     REAL    NOT NULL,
+				last                     REAL    NOT NULL,
+				volume                   INTEGER NOT NULL,
+				open_interest            INTEGER NOT NULL,
+				volatility               REAL    NOT NULL,
+				delta                    REAL    NOT NULL,
+				gamma                    REAL    NOT NULL,
+				theta                    REAL    NOT NULL,
+				vega                     REAL    NOT NULL,
+				rho                      REAL    NOT NULL,
+				PRIMARY KEY (scan_time, option_symbol)
+			);
+			CREATE INDEX idx_option_snapshots_symbol ON option_snapshots (option_symbol, scan_time);
+		`,
+	},
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY
+		)
+	`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := s.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("reading schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning schema_migrations: %w", err)
+		}
+		applied[v] = true
+	}
+	rows.Close()
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("migration %d: begin tx: %w", m.version, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", m.version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, m.version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: recording version: %w", m.version, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: commit: %w", m.version, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IVPoint is one historical implied-volatility observation for an option.
+type IVPoint struct {
+	ScanTime   time.Time
+	Volatility float64
+}
+
+// IVHistory returns every recorded IV observation for optionSymbol, oldest
+// first.
+func (s *Store) IVHistory(ctx context.Context, optionSymbol string) ([]IVPoint, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT scan_time, volatility
+		FROM option_snapshots
+		WHERE option_symbol = ?
+		ORDER BY scan_time ASC
+	`, optionSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("store: querying IV history for %s: %w", optionSymbol, err)
+	}
+	defer rows.Close()
+
+	var points []IVPoint
+	for rows.Next() {
+		var scanTimeStr string
+		var point IVPoint
+		if err := rows.Scan(&scanTimeStr, &point.Volatility); err != nil {
+			return nil, fmt.Errorf("store: scanning IV history row: %w", err)
+		}
+		point.ScanTime, err = time.Parse(timeFormat, scanTimeStr)
+		if err != nil {
+			return nil, fmt.Errorf("store: parsing scan_time %q: %w", scanTimeStr, err)
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// IVRank returns where latest's IV falls within [low, high] of optionSymbol's
+// full recorded history: 0 means latest is the lowest IV ever seen, 1 means
+// the highest. Mirrors the classic "IV Rank" metric.
+func (s *Store) IVRank(ctx context.Context, optionSymbol string) (float64, error) {
+	points, err := s.IVHistory(ctx, optionSymbol)
+	if err != nil {
+		return 0, err
+	}
+	if len(points) == 0 {
+		return 0, fmt.Errorf("store: no IV history for %s", optionSymbol)
+	}
+
+	latest := points[len(points)-1].Volatility
+	low, high := latest, latest
+	for _, p := range points {
+		if p.Volatility < low {
+			low = p.Volatility
+		}
+		if p.Volatility > high {
+			high = p.Volatility
+		}
+	}
+	if high == low {
+		return 0, nil
+	}
+
+	return (latest - low) / (high - low), nil
+}
+
+// IVPercentile returns the fraction of optionSymbol's recorded IV history
+// that falls at or below the latest observation.
+func (s *Store) IVPercentile(ctx context.Context, optionSymbol string) (float64, error) {
+	points, err := s.IVHistory(ctx, optionSymbol)
+	if err != nil {
+		return 0, err
+	}
+	if len(points) == 0 {
+		return 0, fmt.Errorf("store: no IV history for %s", optionSymbol)
+	}
+
+	latest := points[len(points)-1].Volatility
+
+	sorted := make([]float64, len(points))
+	for i, p := range points {
+		sorted[i] = p.Volatility
+	}
+	sort.Float64s(sorted)
+
+	countAtOrBelow := sort.SearchFloat64s(sorted, latest)
+	for countAtOrBelow < len(sorted) && sorted[countAtOrBelow] <= latest {
+		countAtOrBelow++
+	}
+
+	return float64(countAtOrBelow) / float64(len(sorted)), nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+// timeFormat is the on-disk representation of scan_time: RFC 3339 sorts
+// lexicographically the same as chronologically, which the IV history
+// queries rely on.
+const timeFormat = time.RFC3339
+
+// SaveSnapshot writes one row per option, keyed by (scanTime, OptionSymbol).
+// Saving the same (scanTime, OptionSymbol) twice replaces the earlier row.
+func (s *Store) SaveSnapshot(ctx context.Context, scanTime time.Time, options []marketdata.Option) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR REPLACE INTO option_snapshots (
+			scan_time, option_symbol, symbol, option_type, strike_price,
+			expiration_date, days_to_expiration, underlying_price,
+			bid, ask, mark, last, volume, open_interest,
+			volatility, delta, gamma, theta, vega, rho
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("store: prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	scanTimeStr := scanTime.Format(timeFormat)
+	for _, o := range options {
+		if _, err := stmt.ExecContext(ctx,
+			scanTimeStr, o.OptionSymbol, o.Symbol, o.OptionType, o.StrikePrice,
+			o.ExpirationDate, o.DaysToExpiration, o.LastStockPrice,
+			o.Bid, o.Ask, o.Mark, o.Last, o.TotalVolume, o.OpenInterest,
+			o.Volatility, o.Delta, o.Gamma, o.Theta, o.Vega, o.Rho,
+		); err != nil {
+			return fmt.Errorf("store: inserting %s: %w", o.OptionSymbol, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package store persists scan snapshots to a local SQLite database, keyed by
+// (scan_time, option_symbol), so downstream tooling can compute IV
+// rank/percentile over history and backtest new ranking heuristics without
+// re-hitting the Schwab API.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// Store wraps a SQLite database of historical scan snapshots.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and runs
+// any pending migrations.
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: error opening %s: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: error migrating %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
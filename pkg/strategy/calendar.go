@@ -0,0 +1,94 @@
+package strategy
+
+import "github.com/P-Brennan3/options/pkg/marketdata"
+
+// Calendar enumerates calendar spreads: sell a near-term option and buy a
+// longer-dated option at the same strike and type, profiting from the
+// near-term leg decaying faster than the far leg.
+type Calendar struct{}
+
+// Name implements Strategy.
+func (Calendar) Name() string { return "calendar" }
+
+// Enumerate implements Strategy.
+func (Calendar) Enumerate(chain marketdata.OptionsChain) []Trade {
+	byExp := byExpiration(chain)
+	if len(byExp) < 2 {
+		return nil
+	}
+
+	expirations := make([]string, 0, len(byExp))
+	for exp := range byExp {
+		expirations = append(expirations, exp)
+	}
+
+	var trades []Trade
+	for i, nearExp := range expirations {
+		for j, farExp := range expirations {
+			if i == j {
+				continue
+			}
+			near, far := byExp[nearExp], byExp[farExp]
+			if len(near) == 0 || len(far) == 0 {
+				continue
+			}
+			if near[0].DaysToExpiration >= far[0].DaysToExpiration {
+				continue
+			}
+
+			for _, optionType := range []string{"CALL", "PUT"} {
+				nearByType := filterType(near, optionType)
+				farByType := filterType(far, optionType)
+				trades = append(trades, matchCalendarStrikes(optionType, nearByType, farByType)...)
+			}
+		}
+	}
+	return trades
+}
+
+// matchCalendarStrikes pairs up near/far options sharing the same strike.
+func matchCalendarStrikes(optionType string, near, far []marketdata.Option) []Trade {
+	farByStrike := make(map[float64]marketdata.Option, len(far))
+	for _, o := range far {
+		farByStrike[o.StrikePrice] = o
+	}
+
+	var trades []Trade
+	for _, n := range near {
+		f, ok := farByStrike[n.StrikePrice]
+		if !ok {
+			continue
+		}
+		trades = append(trades, buildCalendar(optionType, n, f))
+	}
+	return trades
+}
+
+func buildCalendar(optionType string, near, far marketdata.Option) Trade {
+	legs := []Leg{
+		{Option: near, Side: Short, Quantity: 1},
+		{Option: far, Side: Long, Quantity: 1},
+	}
+	netDebit := legs[0].cost() + legs[1].cost()
+
+	return Trade{
+		// Calendars have no simple closed-form max profit/loss since the
+		// short leg's decay depends on time, not just price; MaxLoss is
+		// capped at the debit paid, MaxProfit is left unset (theoretically
+		// unbounded near the strike as the near leg expires worthless).
+		Strategy: "calendar-" + optionType,
+		Legs:     legs,
+		NetDebit: netDebit,
+		MaxLoss:  netDebit,
+	}
+}
+
+// Score ranks calendars by net theta per dollar of premium paid: the short
+// near-term leg should be decaying faster than the long far-term leg is
+// losing value.
+func (Calendar) Score(t Trade) float64 {
+	if t.NetDebit <= 0 {
+		return 0
+	}
+	return t.Greeks().Theta / t.NetDebit
+}
@@ -0,0 +1,50 @@
+package strategy
+
+import (
+	"sort"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+// byExpiration groups chain's options by expiration date, with each group's
+// options sorted by strike ascending.
+func byExpiration(chain marketdata.OptionsChain) map[string][]marketdata.Option {
+	groups := make(map[string][]marketdata.Option)
+	for _, o := range chain.Options {
+		groups[o.ExpirationDate] = append(groups[o.ExpirationDate], o)
+	}
+	for _, options := range groups {
+		sort.Slice(options, func(i, j int) bool {
+			return options[i].StrikePrice < options[j].StrikePrice
+		})
+	}
+	return groups
+}
+
+// filterType returns only the options of the given type ("CALL" or "PUT").
+func filterType(options []marketdata.Option, optionType string) []marketdata.Option {
+	var out []marketdata.Option
+	for _, o := range options {
+		if o.OptionType == optionType {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// closestStrike returns the option whose strike is nearest to target.
+func closestStrike(options []marketdata.Option, target float64) (marketdata.Option, bool) {
+	var best marketdata.Option
+	var bestDiff float64
+	found := false
+	for _, o := range options {
+		diff := o.StrikePrice - target
+		if diff < 0 {
+			diff = -diff
+		}
+		if !found || diff < bestDiff {
+			best, bestDiff, found = o, diff, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,109 @@
+package strategy
+
+import "github.com/P-Brennan3/options/pkg/marketdata"
+
+// IronCondor enumerates iron condors: a short strangle (sell an OTM call and
+// an OTM put) combined with a further-OTM long strangle that caps the risk
+// on both sides.
+type IronCondor struct {
+	// ShortWidth/LongWidth are, in strikes out from the at-the-money strike,
+	// where the short and long legs sit. LongWidth must be greater than
+	// ShortWidth.
+	ShortWidth int
+	LongWidth  int
+}
+
+// Name implements Strategy.
+func (IronCondor) Name() string { return "iron-condor" }
+
+// Enumerate implements Strategy.
+func (c IronCondor) Enumerate(chain marketdata.OptionsChain) []Trade {
+	shortWidth, longWidth := c.ShortWidth, c.LongWidth
+	if shortWidth < 1 {
+		shortWidth = 1
+	}
+	if longWidth <= shortWidth {
+		longWidth = shortWidth + 1
+	}
+
+	var trades []Trade
+	for _, options := range byExpiration(chain) {
+		if len(options) == 0 {
+			continue
+		}
+		spot := options[0].LastStockPrice
+
+		calls := filterType(options, "CALL")
+		puts := filterType(options, "PUT")
+
+		shortCall, ok := strikeAboveSpot(calls, spot, shortWidth)
+		if !ok {
+			continue
+		}
+		longCall, ok := strikeAboveSpot(calls, spot, longWidth)
+		if !ok {
+			continue
+		}
+		shortPut, ok := strikeBelowSpot(puts, spot, shortWidth)
+		if !ok {
+			continue
+		}
+		longPut, ok := strikeBelowSpot(puts, spot, longWidth)
+		if !ok {
+			continue
+		}
+
+		trades = append(trades, buildIronCondor(shortCall, longCall, shortPut, longPut))
+	}
+	return trades
+}
+
+func buildIronCondor(shortCall, longCall, shortPut, longPut marketdata.Option) Trade {
+	legs := []Leg{
+		{Option: shortCall, Side: Short, Quantity: 1},
+		{Option: longCall, Side: Long, Quantity: 1},
+		{Option: shortPut, Side: Short, Quantity: 1},
+		{Option: longPut, Side: Long, Quantity: 1},
+	}
+
+	netDebit := 0.0
+	for _, leg := range legs {
+		netDebit += leg.cost()
+	}
+
+	callWidth := longCall.StrikePrice - shortCall.StrikePrice
+	putWidth := shortPut.StrikePrice - longPut.StrikePrice
+	width := callWidth
+	if putWidth > width {
+		width = putWidth
+	}
+	width *= contractMultiplier
+
+	credit := -netDebit
+	maxProfit := credit
+	maxLoss := width - credit
+
+	// Breakevens are stock price levels, so they're computed per share
+	// rather than against the contract-scaled credit above.
+	perShareCredit := credit / contractMultiplier
+	return Trade{
+		Strategy:  "iron-condor",
+		Legs:      legs,
+		NetDebit:  netDebit,
+		MaxProfit: maxProfit,
+		MaxLoss:   maxLoss,
+		Breakevens: []float64{
+			shortPut.StrikePrice - perShareCredit,
+			shortCall.StrikePrice + perShareCredit,
+		},
+	}
+}
+
+// Score ranks iron condors by credit-to-max-loss, same as a credit vertical:
+// the more premium collected per dollar at risk, the better.
+func (IronCondor) Score(t Trade) float64 {
+	if t.MaxLoss <= 0 {
+		return 0
+	}
+	return t.Credit() / t.MaxLoss
+}
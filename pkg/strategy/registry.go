@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"sort"
+
+	"github.com/P-Brennan3/options/pkg/marketdata"
+)
+
+// Default is the set of built-in strategies scanned when a caller doesn't
+// pick a subset. Users register new ones by appending a Strategy
+// implementation to this slice, or by building their own slice to pass to
+// Scan.
+var Default = []Strategy{
+	Vertical{},
+	Straddle{},
+	Strangle{Width: 1},
+	IronCondor{ShortWidth: 1, LongWidth: 2},
+	Calendar{},
+	SingleContract{},
+}
+
+// ScoredTrade pairs a Trade with the score its originating Strategy gave it.
+// Callers merging results across multiple chains (e.g. one per underlying)
+// should sort on Score rather than re-deriving it, since only the
+// originating Strategy knows how to score its own Trade shape.
+type ScoredTrade struct {
+	Trade
+	Score float64
+}
+
+// ScanScored runs every strategy in strategies against chain and returns
+// every resulting Trade with its score attached, sorted by descending score.
+func ScanScored(chain marketdata.OptionsChain, strategies []Strategy) []ScoredTrade {
+	var scored []ScoredTrade
+
+	for _, s := range strategies {
+		for _, t := range s.Enumerate(chain) {
+			scored = append(scored, ScoredTrade{Trade: t, Score: s.Score(t)})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	return scored
+}
+
+// Scan runs every strategy in strategies against chain and returns every
+// resulting Trade sorted by descending score, across all strategies. Use
+// ScanScored instead if you need to merge and re-sort results across
+// multiple chains.
+func Scan(chain marketdata.OptionsChain, strategies []Strategy) []Trade {
+	scored := ScanScored(chain, strategies)
+	trades := make([]Trade, len(scored))
+	for i, st := range scored {
+		trades[i] = st.Trade
+	}
+	return trades
+}
@@ -0,0 +1,40 @@
+package strategy
+
+import "github.com/P-Brennan3/options/pkg/marketdata"
+
+// SingleContract enumerates a one-leg "trade" for every option in the chain,
+// scored by implied volatility. It's the multi-leg scanner's built-in
+// replacement for the old standalone IV sort: a long position in a single
+// contract, ranked highest-IV first.
+type SingleContract struct{}
+
+// Name implements Strategy.
+func (SingleContract) Name() string { return "single-contract" }
+
+// Enumerate implements Strategy.
+func (SingleContract) Enumerate(chain marketdata.OptionsChain) []Trade {
+	trades := make([]Trade, 0, len(chain.Options))
+	for _, o := range chain.Options {
+		trades = append(trades, buildSingleContract(o))
+	}
+	return trades
+}
+
+func buildSingleContract(o marketdata.Option) Trade {
+	legs := []Leg{{Option: o, Side: Long, Quantity: 1}}
+	netDebit := legs[0].cost()
+
+	return Trade{
+		Strategy:  "single-" + o.OptionType,
+		Legs:      legs,
+		NetDebit:  netDebit,
+		MaxLoss:   netDebit,
+		MaxProfit: 0, // unbounded (calls) or capped far out-of-the-money (puts)
+	}
+}
+
+// Score ranks single contracts by implied volatility, same as the original
+// standalone IV sort.
+func (SingleContract) Score(t Trade) float64 {
+	return t.Legs[0].Option.Volatility
+}
@@ -0,0 +1,172 @@
+package strategy
+
+import "github.com/P-Brennan3/options/pkg/marketdata"
+
+// Straddle enumerates long straddles: the at-the-money call and put at the
+// same strike and expiration, both bought.
+type Straddle struct{}
+
+// Name implements Strategy.
+func (Straddle) Name() string { return "straddle" }
+
+// Enumerate implements Strategy.
+func (Straddle) Enumerate(chain marketdata.OptionsChain) []Trade {
+	var trades []Trade
+
+	for _, options := range byExpiration(chain) {
+		if len(options) == 0 {
+			continue
+		}
+		spot := options[0].LastStockPrice
+
+		calls := filterType(options, "CALL")
+		puts := filterType(options, "PUT")
+		call, ok := closestStrike(calls, spot)
+		if !ok {
+			continue
+		}
+		put, ok := closestStrike(puts, call.StrikePrice)
+		if !ok || put.StrikePrice != call.StrikePrice {
+			continue
+		}
+
+		trades = append(trades, buildStraddle(call, put))
+	}
+
+	return trades
+}
+
+func buildStraddle(call, put marketdata.Option) Trade {
+	legs := []Leg{
+		{Option: call, Side: Long, Quantity: 1},
+		{Option: put, Side: Long, Quantity: 1},
+	}
+	netDebit := legs[0].cost() + legs[1].cost()
+
+	// Breakevens are stock price levels, so they're computed per share
+	// rather than against the contract-scaled netDebit above.
+	perShareDebit := netDebit / contractMultiplier
+	return Trade{
+		Strategy:  "straddle",
+		Legs:      legs,
+		NetDebit:  netDebit,
+		MaxLoss:   netDebit,
+		MaxProfit: 0, // unbounded either direction
+		Breakevens: []float64{
+			call.StrikePrice - perShareDebit,
+			call.StrikePrice + perShareDebit,
+		},
+	}
+}
+
+// Score ranks straddles by vega per dollar of premium paid: the cheapest way
+// to buy volatility exposure.
+func (Straddle) Score(t Trade) float64 {
+	if t.NetDebit <= 0 {
+		return 0
+	}
+	return t.Greeks().Vega / t.NetDebit
+}
+
+// Strangle enumerates long strangles: an out-of-the-money call and put, same
+// expiration, both bought.
+type Strangle struct {
+	// Width is how far out of the money, in strikes, each leg should be.
+	// A Width of 1 picks the first strike beyond the at-the-money strike on
+	// each side.
+	Width int
+}
+
+// Name implements Strategy.
+func (s Strangle) Name() string { return "strangle" }
+
+// Enumerate implements Strategy.
+func (s Strangle) Enumerate(chain marketdata.OptionsChain) []Trade {
+	width := s.Width
+	if width < 1 {
+		width = 1
+	}
+
+	var trades []Trade
+	for _, options := range byExpiration(chain) {
+		if len(options) == 0 {
+			continue
+		}
+		spot := options[0].LastStockPrice
+
+		calls := filterType(options, "CALL")
+		puts := filterType(options, "PUT")
+
+		call, ok := strikeAboveSpot(calls, spot, width)
+		if !ok {
+			continue
+		}
+		put, ok := strikeBelowSpot(puts, spot, width)
+		if !ok {
+			continue
+		}
+
+		trades = append(trades, buildStrangle(call, put))
+	}
+	return trades
+}
+
+func buildStrangle(call, put marketdata.Option) Trade {
+	legs := []Leg{
+		{Option: call, Side: Long, Quantity: 1},
+		{Option: put, Side: Long, Quantity: 1},
+	}
+	netDebit := legs[0].cost() + legs[1].cost()
+
+	// Breakevens are stock price levels, so they're computed per share
+	// rather than against the contract-scaled netDebit above.
+	perShareDebit := netDebit / contractMultiplier
+	return Trade{
+		Strategy:  "strangle",
+		Legs:      legs,
+		NetDebit:  netDebit,
+		MaxLoss:   netDebit,
+		MaxProfit: 0,
+		Breakevens: []float64{
+			put.StrikePrice - perShareDebit,
+			call.StrikePrice + perShareDebit,
+		},
+	}
+}
+
+// Score ranks strangles the same way as straddles: vega per dollar paid.
+func (Strangle) Score(t Trade) float64 {
+	if t.NetDebit <= 0 {
+		return 0
+	}
+	return t.Greeks().Vega / t.NetDebit
+}
+
+// strikeAboveSpot returns the nth strike above spot.
+func strikeAboveSpot(options []marketdata.Option, spot float64, n int) (marketdata.Option, bool) {
+	count := 0
+	for _, o := range options {
+		if o.StrikePrice > spot {
+			count++
+			if count == n {
+				return o, true
+			}
+		}
+	}
+	return marketdata.Option{}, false
+}
+
+// strikeBelowSpot returns the nth strike below spot, scanning from the
+// highest strike under spot downward.
+func strikeBelowSpot(options []marketdata.Option, spot float64, n int) (marketdata.Option, bool) {
+	count := 0
+	for i := len(options) - 1; i >= 0; i-- {
+		if options[i].StrikePrice < spot {
+			count++
+			if count == n {
+				return options[i], true
+			}
+		}
+	}
+	return marketdata.Option{}, false
+}
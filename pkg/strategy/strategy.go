@@ -0,0 +1,93 @@
+// Package strategy enumerates multi-leg option structures (verticals,
+// straddles, strangles, iron condors, calendars) on top of a per-contract
+// marketdata.OptionsChain, scoring each by a user-selectable heuristic so
+// the single-contract IV sort becomes just one built-in strategy among many.
+package strategy
+
+import (
+	"github.com/P-Brennan3/options/pkg/marketdata"
+	"github.com/P-Brennan3/options/pkg/pricing"
+)
+
+// Side is which side of a leg the trade is on.
+type Side int
+
+const (
+	Long Side = iota
+	Short
+)
+
+// sign returns +1 for Long, -1 for Short, so portfolio greeks and cost can be
+// computed by a single multiply instead of branching everywhere.
+func (s Side) sign() float64 {
+	if s == Short {
+		return -1
+	}
+	return 1
+}
+
+// contractMultiplier is the number of shares a standard equity option
+// contract controls; Mark is quoted per share, so premiums must be scaled by
+// this to get the actual dollar amount paid or received.
+const contractMultiplier = 100
+
+// Leg is one contract within a multi-leg Trade.
+type Leg struct {
+	Option   marketdata.Option
+	Side     Side
+	Quantity int
+}
+
+// cost is the signed premium for this leg: positive means money paid out
+// (long), negative means money received (short), at Quantity contracts.
+func (l Leg) cost() float64 {
+	return l.Side.sign() * l.Option.Mark * float64(l.Quantity) * contractMultiplier
+}
+
+// Trade is a multi-leg position in a single underlying.
+type Trade struct {
+	Strategy string
+	Legs     []Leg
+
+	// NetDebit is positive for a net debit (money paid), negative for a net
+	// credit (money received).
+	NetDebit float64
+
+	MaxProfit  float64
+	MaxLoss    float64
+	Breakevens []float64
+}
+
+// Greeks sums each leg's own greeks, signed by side and scaled by quantity,
+// into the trade's net portfolio greeks.
+func (t Trade) Greeks() pricing.Greeks {
+	var g pricing.Greeks
+	for _, leg := range t.Legs {
+		scale := leg.Side.sign() * float64(leg.Quantity)
+		g.Delta += scale * leg.Option.Delta
+		g.Gamma += scale * leg.Option.Gamma
+		g.Theta += scale * leg.Option.Theta
+		g.Vega += scale * leg.Option.Vega
+		g.Rho += scale * leg.Option.Rho
+	}
+	return g
+}
+
+// Credit returns the net credit received for entering the trade, or 0 if it
+// was a net debit.
+func (t Trade) Credit() float64 {
+	if t.NetDebit < 0 {
+		return -t.NetDebit
+	}
+	return 0
+}
+
+// Strategy enumerates every Trade of a given shape found in chain and scores
+// them so callers can rank across strategies. Implementations register
+// themselves with Register so new strategies plug in without the scanner
+// needing to know about them.
+type Strategy interface {
+	Name() string
+	Enumerate(chain marketdata.OptionsChain) []Trade
+	Score(t Trade) float64
+}
@@ -0,0 +1,98 @@
+package strategy
+
+import "github.com/P-Brennan3/options/pkg/marketdata"
+
+// Vertical enumerates bull/bear call/put verticals: every adjacent pair of
+// strikes, same expiration, same option type, one leg long and one short.
+type Vertical struct{}
+
+// Name implements Strategy.
+func (Vertical) Name() string { return "vertical" }
+
+// Enumerate implements Strategy.
+func (Vertical) Enumerate(chain marketdata.OptionsChain) []Trade {
+	var trades []Trade
+
+	for _, options := range byExpiration(chain) {
+		for _, optionType := range []string{"CALL", "PUT"} {
+			byStrike := filterType(options, optionType)
+			for i := 0; i+1 < len(byStrike); i++ {
+				lower, higher := byStrike[i], byStrike[i+1]
+				trades = append(trades, buildVertical(optionType, lower, higher, true))
+				trades = append(trades, buildVertical(optionType, lower, higher, false))
+			}
+		}
+	}
+
+	return trades
+}
+
+// buildVertical builds one vertical out of adjacent strikes lower < higher.
+// longLower controls direction: buying the lower strike and selling the
+// higher is a bull call / bear put; the reverse is a bear call / bull put.
+func buildVertical(optionType string, lower, higher marketdata.Option, longLower bool) Trade {
+	lowerSide, higherSide := Long, Short
+	if !longLower {
+		lowerSide, higherSide = Short, Long
+	}
+
+	legs := []Leg{
+		{Option: lower, Side: lowerSide, Quantity: 1},
+		{Option: higher, Side: higherSide, Quantity: 1},
+	}
+
+	netDebit := legs[0].cost() + legs[1].cost()
+	width := (higher.StrikePrice - lower.StrikePrice) * contractMultiplier
+
+	var maxProfit, maxLoss float64
+	var breakeven float64
+	switch {
+	case netDebit >= 0:
+		// Debit spread: risk is capped at what was paid, reward is the
+		// width minus that cost.
+		maxLoss = netDebit
+		maxProfit = width - netDebit
+	default:
+		// Credit spread: reward is capped at the credit received, risk is
+		// the width minus that credit.
+		maxProfit = -netDebit
+		maxLoss = width + netDebit
+	}
+
+	// Breakeven is a stock price level, so it's computed per share rather
+	// than against the contract-scaled netDebit above.
+	perShareDebit := netDebit / contractMultiplier
+	if optionType == "CALL" {
+		breakeven = lower.StrikePrice + perShareDebit
+	} else {
+		breakeven = higher.StrikePrice - perShareDebit
+	}
+
+	name := "bull"
+	if (optionType == "CALL" && !longLower) || (optionType == "PUT" && longLower) {
+		name = "bear"
+	}
+	name += "-" + optionType + "-vertical"
+
+	return Trade{
+		Strategy:   name,
+		Legs:       legs,
+		NetDebit:   netDebit,
+		MaxProfit:  maxProfit,
+		MaxLoss:    maxLoss,
+		Breakevens: []float64{breakeven},
+	}
+}
+
+// Score ranks verticals by credit-to-max-loss, the standard metric for
+// defined-risk premium selling; debit verticals (MaxLoss == NetDebit) score
+// on reward-to-risk instead.
+func (Vertical) Score(t Trade) float64 {
+	if t.MaxLoss <= 0 {
+		return 0
+	}
+	if t.NetDebit < 0 {
+		return t.Credit() / t.MaxLoss
+	}
+	return t.MaxProfit / t.MaxLoss
+}
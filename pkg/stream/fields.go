@@ -0,0 +1,62 @@
+package stream
+
+import "encoding/json"
+
+// levelOneOptionsFields lists the LEVELONE_OPTIONS field numbers this
+// package understands, per Schwab's streamer field map. Index 0 (the option
+// symbol) is implicit in every request/response and isn't listed here.
+const levelOneOptionsFields = "2,3,4,10,28,29,30,31,32"
+
+// LEVELONE_OPTIONS field numbers we parse. The streamer only sends fields
+// that changed since the last tick, so every field in levelOneOptionsContent
+// is a pointer.
+type levelOneOptionsContent struct {
+	Symbol     string   `json:"key"`
+	Bid        *float64 `json:"2"`
+	Ask        *float64 `json:"3"`
+	Mark       *float64 `json:"4"`
+	Volatility *float64 `json:"10"`
+	Delta      *float64 `json:"28"`
+	Gamma      *float64 `json:"29"`
+	Theta      *float64 `json:"30"`
+	Vega       *float64 `json:"31"`
+	Rho        *float64 `json:"32"`
+}
+
+// parseLevelOneOptions extracts OptionUpdates from a decoded streamer frame,
+// ignoring any service the frame carries other than LEVELONE_OPTIONS.
+func parseLevelOneOptions(payload []byte) []OptionUpdate {
+	var frame streamData
+	if err := json.Unmarshal(payload, &frame); err != nil {
+		return nil
+	}
+
+	var updates []OptionUpdate
+	for _, d := range frame.Data {
+		if d.Service != levelOneOptionsService {
+			continue
+		}
+		for _, raw := range d.Content {
+			var c levelOneOptionsContent
+			if err := json.Unmarshal(raw, &c); err != nil {
+				continue
+			}
+			if c.Symbol == "" {
+				continue
+			}
+			updates = append(updates, OptionUpdate{
+				OptionSymbol: c.Symbol,
+				Bid:          c.Bid,
+				Ask:          c.Ask,
+				Mark:         c.Mark,
+				Volatility:   c.Volatility,
+				Delta:        c.Delta,
+				Gamma:        c.Gamma,
+				Theta:        c.Theta,
+				Vega:         c.Vega,
+				Rho:          c.Rho,
+			})
+		}
+	}
+	return updates
+}
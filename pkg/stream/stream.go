@@ -0,0 +1,314 @@
+// Package stream connects to Schwab's streamer WebSocket and keeps option
+// quotes live after the initial REST snapshot, so long-running scans don't
+// have to re-poll the chain endpoint to see updated bid/ask/greeks.
+package stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	levelOneOptionsService = "LEVELONE_OPTIONS"
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 60 * time.Second
+)
+
+// OptionUpdate is an incremental quote update for a single option symbol.
+// Zero-value fields mean "unchanged" — Schwab's streamer only sends the
+// fields that actually moved since the last tick.
+type OptionUpdate struct {
+	OptionSymbol string
+	Bid          *float64
+	Ask          *float64
+	Mark         *float64
+	Volatility   *float64
+	Delta        *float64
+	Gamma        *float64
+	Theta        *float64
+	Vega         *float64
+	Rho          *float64
+}
+
+// Config carries the streamer connection details handed back by Schwab's
+// user preference endpoint.
+type Config struct {
+	StreamerURL string
+	CustomerID  string
+	CorrelID    string
+	Channel     string
+	FunctionID  string
+}
+
+// Streamer maintains a connection to Schwab's streamer WebSocket, resubscribing
+// the active symbol set automatically across reconnects.
+type Streamer struct {
+	cfg         Config
+	accessToken func() string
+
+	mu        sync.Mutex
+	symbols   map[string]struct{}
+	requestID int
+	conn      *websocket.Conn // non-nil only while a connection is live
+
+	updates chan OptionUpdate
+}
+
+// New returns a Streamer that has not yet connected. accessToken is called
+// on every (re)connect to get the current bearer token, since Schwab access
+// tokens expire in ~30 minutes and a long-running scan will outlive one —
+// it should read from the same TokenSource a Client refreshes in the
+// background, not return a value captured once at startup. Call Start to
+// connect and begin streaming.
+func New(cfg Config, accessToken func() string) *Streamer {
+	return &Streamer{
+		cfg:         cfg,
+		accessToken: accessToken,
+		symbols:     make(map[string]struct{}),
+		updates:     make(chan OptionUpdate, 256),
+	}
+}
+
+// Updates returns the channel of incremental option quote updates. It is
+// closed when ctx passed to Start is cancelled.
+func (s *Streamer) Updates() <-chan OptionUpdate {
+	return s.updates
+}
+
+// Subscribe adds symbols to the active subscription set. If the streamer is
+// currently connected, a SUBS request for the newly added symbols is sent
+// immediately; otherwise the symbols are folded into the next
+// LOGIN/resubscription on connect.
+func (s *Streamer) Subscribe(symbols ...string) {
+	s.mu.Lock()
+	var added []string
+	for _, sym := range symbols {
+		if _, ok := s.symbols[sym]; ok {
+			continue
+		}
+		s.symbols[sym] = struct{}{}
+		added = append(added, sym)
+	}
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil || len(added) == 0 {
+		return
+	}
+	if err := s.sendSubscribe(conn, added); err != nil {
+		log.Printf("stream: live subscribe failed, will retry on next reconnect: %v", err)
+	}
+}
+
+// Start connects to the streamer and runs the read loop until ctx is
+// cancelled, transparently reconnecting with exponential backoff and
+// resubscribing the active symbol set on every reconnect.
+func (s *Streamer) Start(ctx context.Context) {
+	go func() {
+		defer close(s.updates)
+
+		backoff := minBackoff
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			err := s.runConnection(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Printf("stream: connection error: %v", err)
+			}
+
+			jitter := time.Duration(rand.Int63n(int64(backoff) / 2))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff + jitter):
+			}
+
+			backoff = time.Duration(math.Min(float64(backoff*2), float64(maxBackoff)))
+		}
+	}()
+}
+
+// runConnection dials the streamer, logs in, resubscribes the active symbol
+// set, and reads frames until the connection breaks or ctx is cancelled.
+func (s *Streamer) runConnection(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.cfg.StreamerURL, nil)
+	if err != nil {
+		return fmt.Errorf("dial streamer: %w", err)
+	}
+	defer conn.Close()
+
+	if err := s.login(conn); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	if err := s.resubscribe(conn); err != nil {
+		return fmt.Errorf("resubscribe: %w", err)
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("read message: %w", err)
+		}
+
+		payload, err := decodeFrame(msgType, data)
+		if err != nil {
+			log.Printf("stream: dropping malformed frame: %v", err)
+			continue
+		}
+
+		for _, update := range parseLevelOneOptions(payload) {
+			select {
+			case s.updates <- update:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// decodeFrame transparently inflates gzip-compressed frames; the streamer
+// negotiates compression at LOGIN time but may still send a mix of plain and
+// compressed frames.
+func decodeFrame(msgType int, data []byte) ([]byte, error) {
+	if msgType != websocket.BinaryMessage || len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader: %w", err)
+	}
+	defer gz.Close()
+
+	inflated, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("gzip inflate: %w", err)
+	}
+	return inflated, nil
+}
+
+func (s *Streamer) nextRequestID() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requestID++
+	return s.requestID
+}
+
+func (s *Streamer) login(conn *websocket.Conn) error {
+	req := streamRequest{
+		Requests: []streamCommand{{
+			Service:                "ADMIN",
+			Command:                "LOGIN",
+			RequestID:              s.nextRequestID(),
+			SchwabClientCustomerID: s.cfg.CustomerID,
+			SchwabClientCorrelID:   s.cfg.CorrelID,
+			Parameters: map[string]string{
+				"Authorization":          s.accessToken(),
+				"SchwabClientChannel":    s.cfg.Channel,
+				"SchwabClientFunctionId": s.cfg.FunctionID,
+				"qoslevel":               "0",
+			},
+		}},
+	}
+	return conn.WriteJSON(req)
+}
+
+// resubscribe sends a fresh SUBS request for the entire active symbol set.
+// Called on every (re)connect since Schwab's streamer doesn't remember
+// subscriptions across a dropped socket.
+func (s *Streamer) resubscribe(conn *websocket.Conn) error {
+	s.mu.Lock()
+	symbols := make([]string, 0, len(s.symbols))
+	for sym := range s.symbols {
+		symbols = append(symbols, sym)
+	}
+	s.mu.Unlock()
+
+	if len(symbols) == 0 {
+		return nil
+	}
+	return s.sendSubscribe(conn, symbols)
+}
+
+// sendSubscribe writes a SUBS request for symbols over conn.
+func (s *Streamer) sendSubscribe(conn *websocket.Conn, symbols []string) error {
+	req := streamRequest{
+		Requests: []streamCommand{{
+			Service:                levelOneOptionsService,
+			Command:                "SUBS",
+			RequestID:              s.nextRequestID(),
+			SchwabClientCustomerID: s.cfg.CustomerID,
+			SchwabClientCorrelID:   s.cfg.CorrelID,
+			Parameters: map[string]string{
+				"keys":   joinSymbols(symbols),
+				"fields": levelOneOptionsFields,
+			},
+		}},
+	}
+	return conn.WriteJSON(req)
+}
+
+func joinSymbols(symbols []string) string {
+	out := ""
+	for i, sym := range symbols {
+		if i > 0 {
+			out += ","
+		}
+		out += sym
+	}
+	return out
+}
+
+// streamRequest/streamCommand mirror the envelope Schwab expects for every
+// outbound ADMIN/SUBS/UNSUBS message.
+type streamRequest struct {
+	Requests []streamCommand `json:"requests"`
+}
+
+type streamCommand struct {
+	Service                string            `json:"service"`
+	Command                string            `json:"command"`
+	RequestID              int               `json:"requestid"`
+	SchwabClientCustomerID string            `json:"SchwabClientCustomerId"`
+	SchwabClientCorrelID   string            `json:"SchwabClientCorrelId"`
+	Parameters             map[string]string `json:"parameters"`
+}
+
+// streamData is the envelope Schwab wraps data frames in.
+type streamData struct {
+	Data []struct {
+		Service string            `json:"service"`
+		Content []json.RawMessage `json:"content"`
+	} `json:"data"`
+}